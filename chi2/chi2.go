@@ -0,0 +1,37 @@
+// Package chi2 提供基于卡方检验的特征选择
+//
+// Chi2 是使用 infogain.ChiSquare 打分函数的 infogain.InfoGain，计数、按标签选择特征、
+// 转换和模型格式都与 InfoGain 相同。特征的分数与 sklearn.feature_selection.chi2
+// 在二值词项出现矩阵上的结果相同，见 chi2.py
+package chi2
+
+import (
+	"fmt"
+
+	"github.com/yinziyang/mlkit/infogain"
+)
+
+// Chi2 实现了基于卡方检验的特征选择算法
+// 对于每个类别，按特征与该类别的2×2列联表的卡方统计量选择与其正相关的前N个特征
+type Chi2 struct {
+	*infogain.InfoGain
+}
+
+// NewChi2 创建卡方特征选择模型
+// maxFeatures 的含义与 infogain.NewInfoGain 相同
+func NewChi2(maxFeatures ...int) *Chi2 {
+	return &Chi2{infogain.NewInfoGainWithScorer(infogain.ChiSquare{}, maxFeatures...)}
+}
+
+// Load 从文件加载模型，要求模型使用卡方打分函数；加载失败时模型保持不变
+func (c *Chi2) Load(filename string) error {
+	ig := infogain.NewInfoGain()
+	if err := ig.Load(filename); err != nil {
+		return err
+	}
+	if name := ig.Scorer().Name(); name != (infogain.ChiSquare{}).Name() {
+		return fmt.Errorf("模型的打分函数为 %s，不是卡方", name)
+	}
+	c.InfoGain = ig
+	return nil
+}
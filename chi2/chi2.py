@@ -0,0 +1,23 @@
+from sklearn.feature_extraction.text import CountVectorizer
+from sklearn.feature_selection import chi2
+
+# chi2_test.go 中的期望值由本脚本生成
+# binary=True 得到二值词项出现矩阵，与 Go 版本按文档计数的方式一致
+documents = [
+    "python java 编程 代码",
+    "代码 开发 python 程序 测试",
+    "编程 开发 测试",
+    "数据 分析 python 统计",
+    "机器学习 数据 分析 模型",
+    "网络 服务器 安全",
+    "服务器 网络 运维 监控",
+]
+
+for labels in (["0", "0", "0", "1", "1", "2", "2"], ["a", "a", "a", "b", "b", "b", "b"]):
+    vectorizer = CountVectorizer(token_pattern=r"(?u)\b\w+\b", binary=True)
+    X = vectorizer.fit_transform(documents)
+    scores, pvalues = chi2(X, labels)
+
+    print(f"\n=== labels={labels} ===")
+    for feature, score in sorted(zip(vectorizer.get_feature_names_out(), scores), key=lambda x: (-x[1], x[0])):
+        print(f"{feature!r}: {score!r},")
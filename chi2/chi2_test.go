@@ -0,0 +1,203 @@
+package chi2
+
+import (
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yinziyang/mlkit/infogain"
+)
+
+var (
+	testTexts = []string{
+		"python java 编程 代码",
+		"代码 开发 python 程序 测试",
+		"编程 开发 测试",
+		"数据 分析 python 统计",
+		"机器学习 数据 分析 模型",
+		"网络 服务器 安全",
+		"服务器 网络 运维 监控",
+	}
+	testTargets = []string{"0", "0", "0", "1", "1", "2", "2"}
+)
+
+// TestChi2Fit 的期望值由 chi2.py 生成
+func TestChi2Fit(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+		want    map[string]float64
+	}{
+		{
+			name:    "多分类",
+			targets: testTargets,
+			want: map[string]float64{
+				"分析": 5.0, "数据": 5.0, "服务器": 5.0, "网络": 5.0,
+				"代码": 2.666666666666666, "开发": 2.666666666666666, "测试": 2.666666666666666, "编程": 2.666666666666666,
+				"安全": 2.5, "机器学习": 2.5, "模型": 2.5, "监控": 2.5, "统计": 2.5, "运维": 2.5,
+				"java": 1.333333333333333, "程序": 1.333333333333333,
+				"python": 1.2777777777777777,
+			},
+		},
+		{
+			name:    "二分类",
+			targets: []string{"a", "a", "a", "b", "b", "b", "b"},
+			want: map[string]float64{
+				"代码": 2.6666666666666665, "开发": 2.6666666666666665, "测试": 2.6666666666666665, "编程": 2.6666666666666665,
+				"分析": 1.5, "数据": 1.5, "服务器": 1.5, "网络": 1.5,
+				"java": 1.3333333333333333, "程序": 1.3333333333333333,
+				"安全": 0.75, "机器学习": 0.75, "模型": 0.75, "监控": 0.75, "统计": 0.75, "运维": 0.75,
+				"python": 0.6944444444444442,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChi2()
+			c.Fit(testTexts, tt.targets, strings.Fields)
+
+			scores := c.GetFeatureScores()
+			if len(scores) != len(tt.want) {
+				t.Fatalf("特征数量不匹配: 期望 %d, 得到 %d", len(tt.want), len(scores))
+			}
+			for _, fs := range scores {
+				if want, ok := tt.want[fs.Feature]; !ok || math.Abs(fs.Score-want) > 1e-12 {
+					t.Errorf("特征 %q 的分数不匹配: 期望 %v, 得到 %v", fs.Feature, want, fs.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestChi2FitMaxFeatures(t *testing.T) {
+	c := NewChi2(2)
+	c.Fit(testTexts, testTargets, strings.Fields)
+
+	// 每个类别按卡方统计量选择与其正相关的前2个特征，分数相同时按特征名排序
+	want := []string{"代码", "分析", "开发", "数据", "服务器", "网络"}
+	var got []string
+	for _, fs := range c.GetFeatureScores() {
+		got = append(got, fs.Feature)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("选中的特征不匹配: 期望 %v, 得到 %v", want, got)
+	}
+	if score := c.GetFeatureScores()[0].Score; math.Abs(score-2.666666666666666) > 1e-12 {
+		t.Errorf("选中特征的分数应为卡方统计量, 得到 %v", score)
+	}
+
+	// 多次训练的结果相同
+	for i := 0; i < 10; i++ {
+		other := NewChi2(2)
+		other.Fit(testTexts, testTargets, strings.Fields)
+		if !reflect.DeepEqual(other.GetFeatureScores(), c.GetFeatureScores()) {
+			t.Fatalf("第%d次训练的结果不同", i)
+		}
+	}
+}
+
+func TestChi2TransformWithTokens(t *testing.T) {
+	c := NewChi2(2)
+	X, features := c.FitTransform(testTexts, testTargets, true, strings.Fields)
+
+	if X.Rows != len(testTexts) || X.Cols != len(features) {
+		t.Fatalf("矩阵维度不匹配: 期望 (%d, %d), 得到 (%d, %d)", len(testTexts), len(features), X.Rows, X.Cols)
+	}
+	if err := X.Validate(); err != nil {
+		t.Fatalf("矩阵无效: %v", err)
+	}
+
+	for i, text := range testTexts {
+		tokens := strings.Fields(text)
+		cols, data := X.Row(i)
+		norm := 0.0
+		for k, col := range cols {
+			found := false
+			for _, token := range tokens {
+				found = found || token == features[col]
+			}
+			if !found {
+				t.Errorf("第%d行包含不存在的特征 %q", i, features[col])
+			}
+			norm += data[k] * data[k]
+		}
+		if len(cols) > 0 && math.Abs(norm-1) > 1e-9 {
+			t.Errorf("第%d行的L2范数不为1: %v", i, math.Sqrt(norm))
+		}
+	}
+
+	// TransformWithTokens 与 Transform 的结果相同
+	tokens := make([][]string, len(testTexts))
+	for i, text := range testTexts {
+		tokens[i] = strings.Fields(text)
+	}
+	if got, _ := c.TransformWithTokens(tokens, true); !reflect.DeepEqual(got, X) {
+		t.Errorf("TransformWithTokens() = %+v, 期望 %+v", got, X)
+	}
+}
+
+func TestChi2SaveLoad(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "chi2_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	tmpfile.Close()
+
+	c := NewChi2(2)
+	want, _ := c.FitTransform(testTexts, testTargets, false, strings.Fields)
+	if err := c.Save(tmpfile.Name()); err != nil {
+		t.Fatalf("保存模型失败: %v", err)
+	}
+
+	loaded := NewChi2()
+	if err := loaded.Load(tmpfile.Name()); err != nil {
+		t.Fatalf("加载模型失败: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.GetFeatureScores(), c.GetFeatureScores()) {
+		t.Errorf("特征分数不匹配: 期望 %v, 得到 %v", c.GetFeatureScores(), loaded.GetFeatureScores())
+	}
+	for _, target := range c.GetTargets() {
+		if got, want := loaded.TopFeaturesForClass(target, 0), c.TopFeaturesForClass(target, 0); !reflect.DeepEqual(got, want) {
+			t.Errorf("标签 %q 的特征排名不匹配: 期望 %v, 得到 %v", target, want, got)
+		}
+	}
+	got, _ := loaded.Transform(testTexts, false, strings.Fields)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("加载后的转换结果不匹配:\n期望 %+v\n得到 %+v", want, got)
+	}
+
+	if err := loaded.Load(tmpfile.Name() + ".missing"); err == nil {
+		t.Error("加载不存在的文件应返回错误")
+	}
+}
+
+func TestChi2LoadRejectsOtherScorer(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "chi2_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	tmpfile.Close()
+
+	// 使用信息增益（mutual_information）训练的模型不能作为卡方模型加载
+	ig := infogain.NewInfoGain(2)
+	ig.Fit(testTexts, testTargets, strings.Fields)
+	if err := ig.Save(tmpfile.Name()); err != nil {
+		t.Fatalf("保存模型失败: %v", err)
+	}
+
+	c := NewChi2(2)
+	c.Fit(testTexts, testTargets, strings.Fields)
+	want := c.GetFeatureScores()
+	if err := c.Load(tmpfile.Name()); err == nil {
+		t.Fatal("加载非卡方模型应返回错误")
+	}
+	// 加载失败时模型保持不变
+	if c.Scorer() != (infogain.ChiSquare{}) || !reflect.DeepEqual(c.GetFeatureScores(), want) {
+		t.Errorf("加载失败后模型被修改: 打分函数 %v, 特征分数 %v", c.Scorer(), c.GetFeatureScores())
+	}
+}
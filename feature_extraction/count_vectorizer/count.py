@@ -0,0 +1,33 @@
+from sklearn.feature_extraction.text import CountVectorizer
+
+# count_vectorizer_test.go 中的期望值由本脚本生成
+# analyzer 直接返回已分词的列表，保证 Python 与 Go 的输入完全相同
+docs = [
+    ["python", "java", "编程", "代码", "python"],
+    ["代码", "开发", "python", "程序", "测试"],
+    ["编程", "开发", "测试"],
+    ["数据", "分析", "python", "统计"],
+    ["机器学习", "数据", "分析", "模型", "数据"],
+    ["网络", "服务器", "安全"],
+    ["服务器", "网络", "运维", "监控"],
+]
+
+cases = [
+    dict(),
+    dict(min_df=2),
+    dict(max_df=0.4),
+    dict(min_df=2, max_df=2),
+    dict(max_features=4),
+    dict(min_df=0.25, max_features=3),
+]
+for params in cases:
+    cv = CountVectorizer(analyzer=lambda doc: doc, **params)
+    cv.fit(docs)
+    print(params, cv.get_feature_names_out().tolist())
+
+cv = CountVectorizer(analyzer=lambda doc: doc, min_df=2)
+X = cv.fit_transform(docs)
+print("\nmin_df=2 转换结果:")
+for i in range(X.shape[0]):
+    row = X.getrow(i)
+    print(list(zip(row.indices.tolist(), row.data.tolist())))
@@ -0,0 +1,330 @@
+package count_vectorizer
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/yinziyang/mlkit/feature_extraction/count_vectorizer/count_vectorizerpb"
+	"github.com/yinziyang/mlkit/matrix"
+	"github.com/yinziyang/mlkit/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+// DocFreq 表示文档频率阈值，对应 sklearn 中 min_df/max_df 的 int 和 float 两种取值
+type DocFreq struct {
+	Value float64 // 阈值
+	Ratio bool    // 为true时 Value 是占文档总数的比例，取值范围为[0, 1]；否则为文档数
+}
+
+// DFCount 返回以文档数表示的阈值
+func DFCount(n int) DocFreq {
+	return DocFreq{Value: float64(n)}
+}
+
+// DFRatio 返回以占文档总数的比例表示的阈值
+func DFRatio(r float64) DocFreq {
+	return DocFreq{Value: r, Ratio: true}
+}
+
+// docCount 将阈值换算为文档数
+func (d DocFreq) docCount(numDocs int) float64 {
+	if d.Ratio {
+		return d.Value * float64(numDocs)
+	}
+	return d.Value
+}
+
+// validate 检查阈值是否有效
+func (d DocFreq) validate(name string) error {
+	if d.Ratio {
+		if d.Value < 0 || d.Value > 1 {
+			return fmt.Errorf("%s 的比例必须在[0, 1]范围内，得到 %v", name, d.Value)
+		}
+		return nil
+	}
+	if d.Value < 0 || d.Value != math.Trunc(d.Value) {
+		return fmt.Errorf("%s 的文档数必须为非负整数，得到 %v", name, d.Value)
+	}
+	return nil
+}
+
+// CountVectorizer 将分词后的文本转换为词频矩阵
+// 训练时根据文档频率和词频构建词汇表，转换时统计每个词在文档中出现的次数
+type CountVectorizer struct {
+	MinDF       DocFreq // 文档频率低于该阈值的词会被忽略
+	MaxDF       DocFreq // 文档频率高于该阈值的词会被忽略，可用于过滤语料相关的停用词
+	MaxFeatures int     // 大于0时，只保留在整个语料中出现次数最多的前 MaxFeatures 个词
+	Binary      bool    // 是否将所有非零的词频置为1
+
+	features       []string       // 按字典序排列的词汇表
+	featureToIndex map[string]int // 词到列索引的映射
+}
+
+// NewCountVectorizer 创建词频向量化器
+// 选项的默认值与 sklearn 相同：MinDF=1, MaxDF=1.0, MaxFeatures=0(不限制), Binary=false
+func NewCountVectorizer() *CountVectorizer {
+	return &CountVectorizer{
+		MinDF: DFCount(1),
+		MaxDF: DFRatio(1),
+	}
+}
+
+// Fit 使用文本数据构建词汇表
+// texts: 输入的文本列表
+// tokenizer: 分词函数，用于将文本转换为词列表
+func (cv *CountVectorizer) Fit(texts []string, tokenizer func(string) []string) error {
+	return cv.FitWithTokens(utils.Tokenize(texts, tokenizer))
+}
+
+// Transform 将文本转换为词频矩阵
+func (cv *CountVectorizer) Transform(texts []string, tokenizer func(string) []string) (*matrix.SparseMatrix, error) {
+	return cv.TransformWithTokens(utils.Tokenize(texts, tokenizer))
+}
+
+// FitTransform 组合了Fit和Transform的功能
+func (cv *CountVectorizer) FitTransform(texts []string, tokenizer func(string) []string) (*matrix.SparseMatrix, error) {
+	return cv.FitTransformWithTokens(utils.Tokenize(texts, tokenizer))
+}
+
+// FitWithTokens 使用已分词的文本构建词汇表
+// 先按 MinDF/MaxDF 过滤文档频率不满足要求的词，再按词频保留前 MaxFeatures 个词，
+// 词频相同时按字典序优先，最终的词汇表按字典序排列
+func (cv *CountVectorizer) FitWithTokens(tokens [][]string) error {
+	if err := cv.validate(); err != nil {
+		return err
+	}
+
+	// 统计每个词的文档频率和总词频
+	docFreq := make(map[string]int)
+	termFreq := make(map[string]int)
+	seen := make(map[string]bool)
+	for _, doc := range tokens {
+		clear(seen)
+		for _, word := range doc {
+			termFreq[word]++
+			if !seen[word] {
+				seen[word] = true
+				docFreq[word]++
+			}
+		}
+	}
+	if len(docFreq) == 0 {
+		return fmt.Errorf("词汇表为空，文档中可能没有任何词")
+	}
+
+	maxDocCount := cv.MaxDF.docCount(len(tokens))
+	minDocCount := cv.MinDF.docCount(len(tokens))
+	if maxDocCount < minDocCount {
+		return fmt.Errorf("max_df 对应的文档数(%v)小于 min_df 对应的文档数(%v)", maxDocCount, minDocCount)
+	}
+
+	var features []string
+	for word, df := range docFreq {
+		if float64(df) >= minDocCount && float64(df) <= maxDocCount {
+			features = append(features, word)
+		}
+	}
+	sort.Strings(features)
+
+	if cv.MaxFeatures > 0 && len(features) > cv.MaxFeatures {
+		sort.SliceStable(features, func(i, j int) bool {
+			return termFreq[features[i]] > termFreq[features[j]]
+		})
+		features = features[:cv.MaxFeatures]
+		sort.Strings(features)
+	}
+	if len(features) == 0 {
+		return fmt.Errorf("过滤后没有剩余的词，请降低 min_df 或提高 max_df")
+	}
+
+	cv.setFeatures(features)
+	return nil
+}
+
+// TransformWithTokens 将已分词的文本转换为词频矩阵
+// 矩阵的第i行对应 tokens[i]，列对应词汇表中的词，不在词汇表中的词会被忽略
+func (cv *CountVectorizer) TransformWithTokens(tokens [][]string) (*matrix.SparseMatrix, error) {
+	if cv.featureToIndex == nil {
+		return nil, fmt.Errorf("模型尚未训练")
+	}
+
+	type rowResult struct {
+		cols []int
+		data []float64
+	}
+	rows := make([]rowResult, len(tokens))
+
+	numWorkers := runtime.GOMAXPROCS(0)
+	chunkSize := (len(tokens) + numWorkers - 1) / numWorkers
+	var wg sync.WaitGroup
+	for start := 0; start < len(tokens); start += chunkSize {
+		end := min(start+chunkSize, len(tokens))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				rows[i].cols, rows[i].data = cv.transformRow(tokens[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	nnz := 0
+	for _, row := range rows {
+		nnz += len(row.cols)
+	}
+	X := &matrix.SparseMatrix{
+		Rows:         len(tokens),
+		Cols:         len(cv.features),
+		Data:         make([]float64, 0, nnz),
+		RowPtr:       make([]int, 1, len(tokens)+1),
+		ColIdx:       make([]int, 0, nnz),
+		FeatureNames: cv.GetFeatureNames(),
+	}
+	for _, row := range rows {
+		X.ColIdx = append(X.ColIdx, row.cols...)
+		X.Data = append(X.Data, row.data...)
+		X.RowPtr = append(X.RowPtr, len(X.Data))
+	}
+	return X, nil
+}
+
+// FitTransformWithTokens 组合了FitWithTokens和TransformWithTokens的功能
+func (cv *CountVectorizer) FitTransformWithTokens(tokens [][]string) (*matrix.SparseMatrix, error) {
+	if err := cv.FitWithTokens(tokens); err != nil {
+		return nil, err
+	}
+	return cv.TransformWithTokens(tokens)
+}
+
+// TransformRow 返回一篇已分词的文本在词频矩阵中对应行的列索引（升序）和词频
+func (cv *CountVectorizer) TransformRow(tokens []string) ([]int, []float64, error) {
+	if cv.featureToIndex == nil {
+		return nil, nil, fmt.Errorf("模型尚未训练")
+	}
+	cols, data := cv.transformRow(tokens)
+	return cols, data, nil
+}
+
+// transformRow 统计一篇文本中词汇表内每个词的出现次数
+func (cv *CountVectorizer) transformRow(tokens []string) ([]int, []float64) {
+	counts := make(map[int]float64)
+	for _, word := range tokens {
+		if idx, ok := cv.featureToIndex[word]; ok {
+			counts[idx]++
+		}
+	}
+
+	cols := make([]int, 0, len(counts))
+	for idx := range counts {
+		cols = append(cols, idx)
+	}
+	sort.Ints(cols)
+
+	data := make([]float64, len(cols))
+	for k, col := range cols {
+		if cv.Binary {
+			data[k] = 1
+		} else {
+			data[k] = counts[col]
+		}
+	}
+	return cols, data
+}
+
+// GetFeatureNames 返回按列索引排列的词汇表
+func (cv *CountVectorizer) GetFeatureNames() []string {
+	return append([]string(nil), cv.features...)
+}
+
+// GetVocabulary 返回词到列索引的映射
+func (cv *CountVectorizer) GetVocabulary() map[string]int {
+	vocab := make(map[string]int, len(cv.featureToIndex))
+	for word, idx := range cv.featureToIndex {
+		vocab[word] = idx
+	}
+	return vocab
+}
+
+// Save 将模型保存到文件
+// filename: 保存的文件路径
+// 返回值: 错误信息
+func (cv *CountVectorizer) Save(filename string) error {
+	if cv.featureToIndex == nil {
+		return fmt.Errorf("模型尚未训练，无法保存")
+	}
+
+	model := &count_vectorizerpb.CountVectorizerModel{
+		MinDf:       cv.MinDF.Value,
+		MinDfRatio:  cv.MinDF.Ratio,
+		MaxDf:       cv.MaxDF.Value,
+		MaxDfRatio:  cv.MaxDF.Ratio,
+		MaxFeatures: int32(cv.MaxFeatures),
+		Binary:      cv.Binary,
+		Features:    cv.features,
+	}
+
+	data, err := proto.Marshal(model)
+	if err != nil {
+		return fmt.Errorf("无法序列化模型: %v", err)
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
+
+// Load 从文件加载模型
+// filename: 模型文件路径
+// 返回值: 错误信息
+func (cv *CountVectorizer) Load(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("无法读取文件: %v", err)
+	}
+
+	model := &count_vectorizerpb.CountVectorizerModel{}
+	if err := proto.Unmarshal(data, model); err != nil {
+		return fmt.Errorf("无法反序列化模型: %v", err)
+	}
+
+	features := model.GetFeatures()
+	for i := 1; i < len(features); i++ {
+		if features[i-1] >= features[i] {
+			return fmt.Errorf("词汇表未按字典序排列或存在重复: %q, %q", features[i-1], features[i])
+		}
+	}
+
+	cv.MinDF = DocFreq{Value: model.GetMinDf(), Ratio: model.GetMinDfRatio()}
+	cv.MaxDF = DocFreq{Value: model.GetMaxDf(), Ratio: model.GetMaxDfRatio()}
+	cv.MaxFeatures = int(model.GetMaxFeatures())
+	cv.Binary = model.GetBinary()
+	cv.setFeatures(features)
+
+	return nil
+}
+
+// setFeatures 设置词汇表并重建词到列索引的映射
+func (cv *CountVectorizer) setFeatures(features []string) {
+	cv.features = features
+	cv.featureToIndex = make(map[string]int, len(features))
+	for i, word := range features {
+		cv.featureToIndex[word] = i
+	}
+}
+
+// validate 检查配置是否有效
+func (cv *CountVectorizer) validate() error {
+	if err := cv.MinDF.validate("min_df"); err != nil {
+		return err
+	}
+	if err := cv.MaxDF.validate("max_df"); err != nil {
+		return err
+	}
+	if cv.MaxFeatures < 0 {
+		return fmt.Errorf("max_features 不能为负数，得到 %d", cv.MaxFeatures)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package count_vectorizer
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yinziyang/mlkit/matrix"
+)
+
+var testDocs = [][]string{
+	{"python", "java", "编程", "代码", "python"},
+	{"代码", "开发", "python", "程序", "测试"},
+	{"编程", "开发", "测试"},
+	{"数据", "分析", "python", "统计"},
+	{"机器学习", "数据", "分析", "模型", "数据"},
+	{"网络", "服务器", "安全"},
+	{"服务器", "网络", "运维", "监控"},
+}
+
+// TestCountVectorizerFit 的期望值由 count.py 生成
+func TestCountVectorizerFit(t *testing.T) {
+	tests := []struct {
+		name        string
+		minDF       DocFreq
+		maxDF       DocFreq
+		maxFeatures int
+		want        []string
+	}{
+		{
+			name:  "默认",
+			minDF: DFCount(1), maxDF: DFRatio(1),
+			want: []string{"java", "python", "代码", "分析", "安全", "开发", "数据", "服务器", "机器学习", "模型", "测试", "监控", "程序", "统计", "编程", "网络", "运维"},
+		},
+		{
+			name:  "min_df=2",
+			minDF: DFCount(2), maxDF: DFRatio(1),
+			want: []string{"python", "代码", "分析", "开发", "数据", "服务器", "测试", "编程", "网络"},
+		},
+		{
+			name:  "max_df=0.4",
+			minDF: DFCount(1), maxDF: DFRatio(0.4),
+			want: []string{"java", "代码", "分析", "安全", "开发", "数据", "服务器", "机器学习", "模型", "测试", "监控", "程序", "统计", "编程", "网络", "运维"},
+		},
+		{
+			name:  "min_df=2,max_df=2",
+			minDF: DFCount(2), maxDF: DFCount(2),
+			want: []string{"代码", "分析", "开发", "数据", "服务器", "测试", "编程", "网络"},
+		},
+		{
+			name:  "max_features=4",
+			minDF: DFCount(1), maxDF: DFRatio(1), maxFeatures: 4,
+			want: []string{"python", "代码", "分析", "数据"},
+		},
+		{
+			name:  "min_df=0.25,max_features=3",
+			minDF: DFRatio(0.25), maxDF: DFRatio(1), maxFeatures: 3,
+			want: []string{"python", "代码", "数据"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := NewCountVectorizer()
+			cv.MinDF, cv.MaxDF, cv.MaxFeatures = tt.minDF, tt.maxDF, tt.maxFeatures
+			if err := cv.FitWithTokens(testDocs); err != nil {
+				t.Fatalf("FitWithTokens() 失败: %v", err)
+			}
+			if got := cv.GetFeatureNames(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("词汇表不匹配:\n期望 %v\n得到 %v", tt.want, got)
+			}
+			for i, word := range tt.want {
+				if idx := cv.GetVocabulary()[word]; idx != i {
+					t.Errorf("词 %q 的索引不匹配: 期望 %d, 得到 %d", word, i, idx)
+				}
+			}
+		})
+	}
+}
+
+// TestCountVectorizerTransform 的期望值由 count.py 生成
+func TestCountVectorizerTransform(t *testing.T) {
+	cv := NewCountVectorizer()
+	cv.MinDF = DFCount(2)
+	X, err := cv.FitTransformWithTokens(testDocs)
+	if err != nil {
+		t.Fatalf("FitTransformWithTokens() 失败: %v", err)
+	}
+
+	want := matrix.DenseToSparse([][]float64{
+		{2, 1, 0, 0, 0, 0, 0, 1, 0},
+		{1, 1, 0, 1, 0, 0, 1, 0, 0},
+		{0, 0, 0, 1, 0, 0, 1, 1, 0},
+		{1, 0, 1, 0, 1, 0, 0, 0, 0},
+		{0, 0, 1, 0, 2, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 1, 0, 0, 1},
+		{0, 0, 0, 0, 0, 1, 0, 0, 1},
+	})
+	want.FeatureNames = cv.GetFeatureNames()
+	if !reflect.DeepEqual(X, want) {
+		t.Errorf("转换结果不匹配:\n期望 %+v\n得到 %+v", want, X)
+	}
+
+	// Binary 模式下所有非零值为1，逐行转换的结果与整体转换相同
+	cv.Binary = true
+	X, err = cv.TransformWithTokens(testDocs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, doc := range testDocs {
+		cols, data, err := cv.TransformRow(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantCols, wantData := X.Row(i)
+		if !reflect.DeepEqual(cols, wantCols) || !reflect.DeepEqual(data, wantData) {
+			t.Errorf("第%d行: TransformRow() = %v %v, 期望 %v %v", i, cols, data, wantCols, wantData)
+		}
+		for _, v := range data {
+			if v != 1 {
+				t.Errorf("第%d行: Binary 模式下的值应为1，得到 %v", i, data)
+				break
+			}
+		}
+	}
+}
+
+func TestCountVectorizerErrors(t *testing.T) {
+	cv := NewCountVectorizer()
+	if _, err := cv.TransformWithTokens(testDocs); err == nil {
+		t.Error("未训练时 TransformWithTokens() 应返回错误")
+	}
+	if err := cv.Save(filepath.Join(t.TempDir(), "cv.pb")); err == nil {
+		t.Error("未训练时 Save() 应返回错误")
+	}
+	if err := cv.FitWithTokens([][]string{{}, {}}); err == nil {
+		t.Error("词汇表为空时应返回错误")
+	}
+
+	tests := []struct {
+		name  string
+		setup func(cv *CountVectorizer)
+	}{
+		{"max_df小于min_df", func(cv *CountVectorizer) { cv.MinDF, cv.MaxDF = DFCount(3), DFRatio(0.2) }},
+		{"过滤后为空", func(cv *CountVectorizer) { cv.MinDF = DFCount(7) }},
+		{"比例超出范围", func(cv *CountVectorizer) { cv.MaxDF = DFRatio(1.5) }},
+		{"文档数不是整数", func(cv *CountVectorizer) { cv.MinDF = DocFreq{Value: 1.5} }},
+		{"max_features为负数", func(cv *CountVectorizer) { cv.MaxFeatures = -1 }},
+	}
+	for _, tt := range tests {
+		cv := NewCountVectorizer()
+		tt.setup(cv)
+		if err := cv.FitWithTokens(testDocs); err == nil {
+			t.Errorf("%s: FitWithTokens() 应返回错误", tt.name)
+		}
+	}
+}
+
+func TestCountVectorizerSaveLoad(t *testing.T) {
+	texts := make([]string, len(testDocs))
+	for i, doc := range testDocs {
+		texts[i] = strings.Join(doc, " ")
+	}
+
+	cv := NewCountVectorizer()
+	cv.MaxDF = DFRatio(0.4)
+	cv.MaxFeatures = 6
+	cv.Binary = true
+	want, err := cv.FitTransform(texts, strings.Fields)
+	if err != nil {
+		t.Fatalf("FitTransform() 失败: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "count_vectorizer.pb")
+	if err := cv.Save(filename); err != nil {
+		t.Fatalf("Save() 失败: %v", err)
+	}
+
+	loaded := &CountVectorizer{}
+	if err := loaded.Load(filename); err != nil {
+		t.Fatalf("Load() 失败: %v", err)
+	}
+	if loaded.MinDF != cv.MinDF || loaded.MaxDF != cv.MaxDF || loaded.MaxFeatures != cv.MaxFeatures || loaded.Binary != cv.Binary {
+		t.Errorf("加载后的配置不匹配: 期望 %+v, 得到 %+v", cv, loaded)
+	}
+	got, err := loaded.Transform(texts, strings.Fields)
+	if err != nil {
+		t.Fatalf("Transform() 失败: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("加载后的转换结果不匹配:\n期望 %+v\n得到 %+v", want, got)
+	}
+}
@@ -0,0 +1,16 @@
+syntax = "proto3";
+
+package count_vectorizer_model;
+
+option go_package = "./;count_vectorizerpb";
+
+// CountVectorizerModel 存储词频向量化模型的配置和词汇表
+message CountVectorizerModel {
+  double min_df = 1;
+  bool min_df_ratio = 2;         // min_df 是否为占文档总数的比例
+  double max_df = 3;
+  bool max_df_ratio = 4;         // max_df 是否为占文档总数的比例
+  int32 max_features = 5;
+  bool binary = 6;
+  repeated string features = 7;  // 按字典序排列的词汇表，下标即列索引
+}
@@ -0,0 +1,204 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v5.26.1
+// source: count_vectorizer.pb
+
+package count_vectorizerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CountVectorizerModel 存储词频向量化模型的配置和词汇表
+type CountVectorizerModel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinDf       float64  `protobuf:"fixed64,1,opt,name=min_df,json=minDf,proto3" json:"min_df,omitempty"`
+	MinDfRatio  bool     `protobuf:"varint,2,opt,name=min_df_ratio,json=minDfRatio,proto3" json:"min_df_ratio,omitempty"` // min_df 是否为占文档总数的比例
+	MaxDf       float64  `protobuf:"fixed64,3,opt,name=max_df,json=maxDf,proto3" json:"max_df,omitempty"`
+	MaxDfRatio  bool     `protobuf:"varint,4,opt,name=max_df_ratio,json=maxDfRatio,proto3" json:"max_df_ratio,omitempty"` // max_df 是否为占文档总数的比例
+	MaxFeatures int32    `protobuf:"varint,5,opt,name=max_features,json=maxFeatures,proto3" json:"max_features,omitempty"`
+	Binary      bool     `protobuf:"varint,6,opt,name=binary,proto3" json:"binary,omitempty"`
+	Features    []string `protobuf:"bytes,7,rep,name=features,proto3" json:"features,omitempty"` // 按字典序排列的词汇表，下标即列索引
+}
+
+func (x *CountVectorizerModel) Reset() {
+	*x = CountVectorizerModel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_count_vectorizer_pb_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountVectorizerModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountVectorizerModel) ProtoMessage() {}
+
+func (x *CountVectorizerModel) ProtoReflect() protoreflect.Message {
+	mi := &file_count_vectorizer_pb_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountVectorizerModel.ProtoReflect.Descriptor instead.
+func (*CountVectorizerModel) Descriptor() ([]byte, []int) {
+	return file_count_vectorizer_pb_rawDescGZIP(), []int{0}
+}
+
+func (x *CountVectorizerModel) GetMinDf() float64 {
+	if x != nil {
+		return x.MinDf
+	}
+	return 0
+}
+
+func (x *CountVectorizerModel) GetMinDfRatio() bool {
+	if x != nil {
+		return x.MinDfRatio
+	}
+	return false
+}
+
+func (x *CountVectorizerModel) GetMaxDf() float64 {
+	if x != nil {
+		return x.MaxDf
+	}
+	return 0
+}
+
+func (x *CountVectorizerModel) GetMaxDfRatio() bool {
+	if x != nil {
+		return x.MaxDfRatio
+	}
+	return false
+}
+
+func (x *CountVectorizerModel) GetMaxFeatures() int32 {
+	if x != nil {
+		return x.MaxFeatures
+	}
+	return 0
+}
+
+func (x *CountVectorizerModel) GetBinary() bool {
+	if x != nil {
+		return x.Binary
+	}
+	return false
+}
+
+func (x *CountVectorizerModel) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+var File_count_vectorizer_pb protoreflect.FileDescriptor
+
+var file_count_vectorizer_pb_rawDesc = []byte{
+	0x0a, 0x13, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x7a,
+	0x65, 0x72, 0x2e, 0x70, 0x62, 0x12, 0x16, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x22, 0xdf, 0x01,
+	0x0a, 0x14, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x7a, 0x65,
+	0x72, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x66,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x44, 0x66, 0x12, 0x20, 0x0a,
+	0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x66, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x44, 0x66, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12,
+	0x15, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x6d, 0x61, 0x78, 0x44, 0x66, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x66,
+	0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x44, 0x66, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f,
+	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x42,
+	0x17, 0x5a, 0x15, 0x2e, 0x2f, 0x3b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x76, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x69, 0x7a, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_count_vectorizer_pb_rawDescOnce sync.Once
+	file_count_vectorizer_pb_rawDescData = file_count_vectorizer_pb_rawDesc
+)
+
+func file_count_vectorizer_pb_rawDescGZIP() []byte {
+	file_count_vectorizer_pb_rawDescOnce.Do(func() {
+		file_count_vectorizer_pb_rawDescData = protoimpl.X.CompressGZIP(file_count_vectorizer_pb_rawDescData)
+	})
+	return file_count_vectorizer_pb_rawDescData
+}
+
+var file_count_vectorizer_pb_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_count_vectorizer_pb_goTypes = []interface{}{
+	(*CountVectorizerModel)(nil), // 0: count_vectorizer_model.CountVectorizerModel
+}
+var file_count_vectorizer_pb_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_count_vectorizer_pb_init() }
+func file_count_vectorizer_pb_init() {
+	if File_count_vectorizer_pb != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_count_vectorizer_pb_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountVectorizerModel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_count_vectorizer_pb_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_count_vectorizer_pb_goTypes,
+		DependencyIndexes: file_count_vectorizer_pb_depIdxs,
+		MessageInfos:      file_count_vectorizer_pb_msgTypes,
+	}.Build()
+	File_count_vectorizer_pb = out.File
+	file_count_vectorizer_pb_rawDesc = nil
+	file_count_vectorizer_pb_goTypes = nil
+	file_count_vectorizer_pb_depIdxs = nil
+}
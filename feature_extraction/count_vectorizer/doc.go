@@ -0,0 +1,30 @@
+/*
+Package count_vectorizer 实现了词频向量化，这是一个对 sklearn.feature_extraction.text.CountVectorizer 的 Go 语言实现。
+参考文档：https://scikit-learn.org/1.5/modules/generated/sklearn.feature_extraction.text.CountVectorizer.html
+
+训练过程：
+  - 统计每个词的文档频率(df)和在整个语料中的出现次数(tf)
+  - 只保留 min_df <= df <= max_df 的词，阈值可以是文档数(DFCount)或比例(DFRatio)
+  - 设置了 max_features 时，按 tf 从高到低保留前 max_features 个词，tf 相同时按字典序优先
+  - 词汇表按字典序排列，下标即列索引
+
+转换时统计每个词在文档中出现的次数，不在词汇表中的词会被忽略。与 InfoGain 不同，训练不需要标签。
+
+Python 与 Go 实现对比：
+
+Python 版本：
+
+	from sklearn.feature_extraction.text import CountVectorizer
+	cv = CountVectorizer(min_df=2, max_df=0.9, max_features=10000)
+	X = cv.fit_transform(texts)
+
+Go 版本：
+
+	cv := count_vectorizer.NewCountVectorizer()
+	cv.MinDF = count_vectorizer.DFCount(2)
+	cv.MaxDF = count_vectorizer.DFRatio(0.9)
+	cv.MaxFeatures = 10000
+	X, err := cv.FitTransform(texts, strings.Fields)
+	err = cv.Save("count_vectorizer.pb")
+*/
+package count_vectorizer
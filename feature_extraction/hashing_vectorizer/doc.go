@@ -0,0 +1,32 @@
+/*
+Package hashing_vectorizer 实现了基于特征哈希的文本向量化，这是一个对 sklearn.feature_extraction.text.HashingVectorizer 的 Go 语言实现。
+参考文档：https://scikit-learn.org/1.5/modules/generated/sklearn.feature_extraction.text.HashingVectorizer.html
+
+每个词经过 MurmurHash3 (32位, seed=0) 哈希后映射到固定数量的列：
+
+	h     = murmurhash3_32(token)
+	index = |h| mod n_features
+	value = sign(h)  (alternate_sign=true 时，否则为1)
+
+同一行中映射到同一列的值会累加，随后可选地二值化并按行归一化。
+
+主要特点：
+  - 无需训练，不保存词汇表，只有配置需要持久化
+  - 带符号哈希，使冲突的特征在期望上相互抵消，减小冲突带来的偏差
+  - 支持 l1/l2/max 归一化
+  - 在分词结果相同时，输出与 sklearn 完全一致
+
+Python 与 Go 实现对比：
+
+Python 版本：
+
+	from sklearn.feature_extraction.text import HashingVectorizer
+	hv = HashingVectorizer(n_features=2**20, alternate_sign=True, norm="l2")
+	X = hv.transform(texts)
+
+Go 版本：
+
+	hv := hashing_vectorizer.NewHashingVectorizer(1 << 20)
+	X, _ := hv.Transform(texts, tokenizer)
+*/
+package hashing_vectorizer
@@ -0,0 +1,30 @@
+from sklearn.feature_extraction.text import HashingVectorizer
+from sklearn.utils import murmurhash3_32
+
+# hashing_vectorizer_test.go 中的期望值由本脚本生成
+# analyzer 直接返回已分词的列表，保证 Python 与 Go 的输入完全相同
+docs = [
+    ["python", "java", "编程", "代码"],
+    ["代码", "开发", "python", "程序", "测试", "python"],
+    [],
+    ["数据", "分析", "python", "统计"],
+]
+
+print("murmurhash3_32('foo') =", murmurhash3_32("foo"))
+print("murmurhash3_32('foo', positive=True) =", murmurhash3_32("foo", positive=True))
+for doc in docs:
+    print([(token, murmurhash3_32(token)) for token in doc])
+
+cases = [
+    dict(alternate_sign=True, norm=None),
+    dict(alternate_sign=True, norm="l2"),
+    dict(alternate_sign=False, norm="l1"),
+    dict(alternate_sign=True, norm=None, binary=True),
+]
+for params in cases:
+    hv = HashingVectorizer(n_features=16, analyzer=lambda doc: doc, **params)
+    X = hv.transform(docs)
+    print(f"\n=== {params} ===")
+    for i in range(X.shape[0]):
+        row = X.getrow(i)
+        print(list(zip(row.indices.tolist(), row.data.tolist())))
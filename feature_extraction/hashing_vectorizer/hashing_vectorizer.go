@@ -0,0 +1,221 @@
+package hashing_vectorizer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/yinziyang/mlkit/matrix"
+	"github.com/yinziyang/mlkit/utils"
+)
+
+// DefaultNFeatures 是默认的列数，与 sklearn 相同
+const DefaultNFeatures = 1 << 20
+
+// HashingVectorizer 使用特征哈希将分词后的文本转换为稀疏矩阵
+// 模型是无状态的，不需要训练，可以在多个协程中同时使用。
+// 与 sklearn 相同，符号相反、相互抵消的冲突特征会以显式的0保留在结果中，Binary 为 true 时其值为1
+type HashingVectorizer struct {
+	NFeatures     int         // 输出矩阵的列数
+	AlternateSign bool        // 是否根据哈希值的符号为特征值加上正负号，使冲突在期望上相互抵消
+	Norm          matrix.Norm // 每行的归一化方式
+	Binary        bool        // 是否将所有非零值置为1（在归一化之前）
+}
+
+// NewHashingVectorizer 创建特征哈希向量化器
+// nFeatures 为输出的列数，小于等于0时使用 DefaultNFeatures；
+// 其余选项与 sklearn 的默认值相同：AlternateSign=true, Norm=L2, Binary=false
+func NewHashingVectorizer(nFeatures int) *HashingVectorizer {
+	if nFeatures <= 0 {
+		nFeatures = DefaultNFeatures
+	}
+	return &HashingVectorizer{
+		NFeatures:     nFeatures,
+		AlternateSign: true,
+		Norm:          matrix.NormL2,
+	}
+}
+
+// Transform 将文本转换为特征矩阵
+// texts: 输入的文本列表
+// tokenizer: 分词函数，例如 func(s string) []string { return ngram.NGram(s, 2) }
+func (hv *HashingVectorizer) Transform(texts []string, tokenizer func(string) []string) (*matrix.SparseMatrix, error) {
+	return hv.TransformWithTokens(utils.Tokenize(texts, tokenizer))
+}
+
+// TransformWithTokens 将已分词的文本转换为特征矩阵，矩阵的第i行对应 tokens[i]
+func (hv *HashingVectorizer) TransformWithTokens(tokens [][]string) (*matrix.SparseMatrix, error) {
+	if err := hv.validate(); err != nil {
+		return nil, err
+	}
+
+	type rowResult struct {
+		cols []int
+		data []float64
+	}
+	rows := make([]rowResult, len(tokens))
+
+	// 各行相互独立，按行划分给多个协程
+	numWorkers := runtime.NumCPU()
+	chunkSize := (len(tokens) + numWorkers - 1) / numWorkers
+	var wg sync.WaitGroup
+	for start := 0; start < len(tokens); start += chunkSize {
+		end := min(start+chunkSize, len(tokens))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				rows[i].cols, rows[i].data = hv.transformRow(tokens[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	nnz := 0
+	for _, row := range rows {
+		nnz += len(row.cols)
+	}
+	X := &matrix.SparseMatrix{
+		Rows:   len(tokens),
+		Cols:   hv.NFeatures,
+		Data:   make([]float64, 0, nnz),
+		RowPtr: make([]int, 1, len(tokens)+1),
+		ColIdx: make([]int, 0, nnz),
+	}
+	for _, row := range rows {
+		X.ColIdx = append(X.ColIdx, row.cols...)
+		X.Data = append(X.Data, row.data...)
+		X.RowPtr = append(X.RowPtr, len(X.Data))
+	}
+	return X, nil
+}
+
+// TransformRow 返回一篇已分词的文本在特征矩阵中对应行的列索引（升序）和值
+func (hv *HashingVectorizer) TransformRow(tokens []string) ([]int, []float64, error) {
+	if err := hv.validate(); err != nil {
+		return nil, nil, err
+	}
+	cols, data := hv.transformRow(tokens)
+	return cols, data, nil
+}
+
+// transformRow 计算一行的特征，调用前需保证配置有效
+func (hv *HashingVectorizer) transformRow(tokens []string) ([]int, []float64) {
+	type entry struct {
+		col  int
+		sign float64
+	}
+	entries := make([]entry, len(tokens))
+	for i, token := range tokens {
+		entries[i].col, entries[i].sign = hv.hashToken(token)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].col < entries[j].col })
+
+	// 累加冲突的特征，相互抵消后为0的元素保留为显式的0
+	cols := make([]int, 0, len(entries))
+	data := make([]float64, 0, len(entries))
+	for _, e := range entries {
+		if n := len(cols); n > 0 && cols[n-1] == e.col {
+			data[n-1] += e.sign
+			continue
+		}
+		cols = append(cols, e.col)
+		data = append(data, e.sign)
+	}
+	if hv.Binary {
+		for k := range data {
+			data[k] = 1
+		}
+	}
+
+	row := &matrix.SparseMatrix{
+		Rows:   1,
+		Cols:   hv.NFeatures,
+		Data:   data,
+		RowPtr: []int{0, len(data)},
+		ColIdx: cols,
+	}
+	row.NormalizeRows(hv.Norm)
+
+	return cols, data
+}
+
+// hashToken 返回词对应的列索引和符号
+func (hv *HashingVectorizer) hashToken(token string) (int, float64) {
+	h := int32(murmur3([]byte(token), 0))
+
+	// 与 sklearn 相同：取哈希值的绝对值作为索引，math.MinInt32 的绝对值为 2^31
+	abs := int64(h)
+	if abs < 0 {
+		abs = -abs
+	}
+	col := int(abs % int64(hv.NFeatures))
+
+	if hv.AlternateSign && h < 0 {
+		return col, -1
+	}
+	return col, 1
+}
+
+// validate 检查配置是否有效
+func (hv *HashingVectorizer) validate() error {
+	if hv.NFeatures <= 0 {
+		return fmt.Errorf("NFeatures 必须为正数，得到 %d", hv.NFeatures)
+	}
+	switch hv.Norm {
+	case matrix.NormNone, matrix.NormL1, matrix.NormL2, matrix.NormMax:
+	default:
+		return fmt.Errorf("不支持的归一化方式: %v", hv.Norm)
+	}
+	return nil
+}
+
+// murmur3 计算 MurmurHash3 x86 32位哈希值，与 sklearn.utils.murmurhash3_32 相同
+func murmur3(data []byte, seed uint32) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+
+	h := seed
+	n := len(data)
+	for i := 0; i+4 <= n; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	// 处理剩余不足4字节的部分
+	tail := data[n&^3:]
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(n)
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
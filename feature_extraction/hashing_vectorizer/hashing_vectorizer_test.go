@@ -0,0 +1,181 @@
+package hashing_vectorizer
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yinziyang/mlkit/matrix"
+)
+
+func TestMurmur3(t *testing.T) {
+	tests := []struct {
+		key  string
+		want int32
+	}{
+		{"", 0},
+		{"foo", -156908512},
+		{"python", 683459885},
+		{"编程", -814163832},
+		{"统计", -1444086064},
+	}
+	for _, tt := range tests {
+		if got := int32(murmur3([]byte(tt.key), 0)); got != tt.want {
+			t.Errorf("murmur3(%q) = %d, 期望 %d", tt.key, got, tt.want)
+		}
+	}
+}
+
+// TestHashingVectorizer 的期望值由 hashing.py 生成
+func TestHashingVectorizer(t *testing.T) {
+	docs := [][]string{
+		{"python", "java", "编程", "代码"},
+		{"代码", "开发", "python", "程序", "测试", "python"},
+		{},
+		{"数据", "分析", "python", "统计"},
+	}
+
+	type entry struct {
+		col int
+		v   float64
+	}
+	r8, r6 := 1/math.Sqrt(8), 1/math.Sqrt(6)
+	tests := []struct {
+		name string
+		hv   HashingVectorizer
+		want [][]entry
+	}{
+		{
+			name: "带符号",
+			hv:   HashingVectorizer{NFeatures: 16, AlternateSign: true},
+			want: [][]entry{
+				{{8, -1}, {10, 1}, {13, 1}, {14, 1}},
+				{{8, -1}, {9, 1}, {10, 1}, {12, -1}, {13, 2}},
+				{},
+				{{0, -1}, {8, -1}, {13, 2}},
+			},
+		},
+		{
+			name: "带符号L2",
+			hv:   HashingVectorizer{NFeatures: 16, AlternateSign: true, Norm: matrix.NormL2},
+			want: [][]entry{
+				{{8, -0.5}, {10, 0.5}, {13, 0.5}, {14, 0.5}},
+				{{8, -r8}, {9, r8}, {10, r8}, {12, -r8}, {13, 2 * r8}},
+				{},
+				{{0, -r6}, {8, -r6}, {13, 2 * r6}},
+			},
+		},
+		{
+			name: "无符号L1",
+			hv:   HashingVectorizer{NFeatures: 16, Norm: matrix.NormL1},
+			want: [][]entry{
+				{{8, 0.25}, {10, 0.25}, {13, 0.25}, {14, 0.25}},
+				{{8, 1.0 / 6}, {9, 1.0 / 6}, {10, 1.0 / 6}, {12, 1.0 / 6}, {13, 1.0 / 3}},
+				{},
+				{{0, 0.25}, {8, 0.25}, {13, 0.5}},
+			},
+		},
+		{
+			name: "二值",
+			hv:   HashingVectorizer{NFeatures: 16, AlternateSign: true, Binary: true},
+			want: [][]entry{
+				{{8, 1}, {10, 1}, {13, 1}, {14, 1}},
+				{{8, 1}, {9, 1}, {10, 1}, {12, 1}, {13, 1}},
+				{},
+				{{0, 1}, {8, 1}, {13, 1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			X, err := tt.hv.TransformWithTokens(docs)
+			if err != nil {
+				t.Fatalf("TransformWithTokens() 失败: %v", err)
+			}
+			if err := X.Validate(); err != nil {
+				t.Fatalf("矩阵无效: %v", err)
+			}
+			if X.Rows != len(docs) || X.Cols != 16 {
+				t.Fatalf("矩阵维度不匹配: 期望 (%d, 16), 得到 (%d, %d)", len(docs), X.Rows, X.Cols)
+			}
+
+			for i, want := range tt.want {
+				cols, data := X.Row(i)
+				if len(cols) != len(want) {
+					t.Errorf("第%d行: 得到 %v %v, 期望 %v", i, cols, data, want)
+					continue
+				}
+				for k, e := range want {
+					if cols[k] != e.col || math.Abs(data[k]-e.v) > 1e-12 {
+						t.Errorf("第%d行: 得到 %v %v, 期望 %v", i, cols, data, want)
+						break
+					}
+				}
+
+				rowCols, rowData, err := tt.hv.TransformRow(docs[i])
+				if err != nil {
+					t.Fatalf("TransformRow() 失败: %v", err)
+				}
+				if len(rowCols) != len(cols) || (len(cols) > 0 && (!reflect.DeepEqual(rowCols, cols) || !reflect.DeepEqual(rowData, data))) {
+					t.Errorf("第%d行: TransformRow() = %v %v, 期望 %v %v", i, rowCols, rowData, cols, data)
+				}
+			}
+		})
+	}
+}
+
+func TestHashingVectorizer_Cancel(t *testing.T) {
+	// 找到与 "python" 映射到同一列但符号相反的词，两者相互抵消，与 sklearn 相同保留为显式的0
+	hv := NewHashingVectorizer(16)
+	col, sign := hv.hashToken("python")
+	var other string
+	for i := 0; other == ""; i++ {
+		token := strings.Repeat("x", i)
+		if c, s := hv.hashToken(token); c == col && s == -sign {
+			other = token
+		}
+	}
+
+	X, err := hv.TransformWithTokens([][]string{{"python", other}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cols, data := X.Row(0); !reflect.DeepEqual(cols, []int{col}) || !reflect.DeepEqual(data, []float64{0}) {
+		t.Errorf("相互抵消的特征应保留为显式的0，得到 %v %v", cols, data)
+	}
+
+	// Binary 为 true 时抵消后的元素也置为1
+	hv.Binary = true
+	cols, data, err := hv.TransformRow([]string{"python", other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cols, []int{col}) || !reflect.DeepEqual(data, []float64{1}) {
+		t.Errorf("Binary 时抵消后的元素应为1，得到 %v %v", cols, data)
+	}
+}
+
+func TestHashingVectorizer_Transform(t *testing.T) {
+	hv := NewHashingVectorizer(0)
+	if hv.NFeatures != DefaultNFeatures || !hv.AlternateSign || hv.Norm != matrix.NormL2 {
+		t.Errorf("默认配置不正确: %+v", hv)
+	}
+
+	texts := []string{"python java", "", "数据 分析"}
+	X, err := hv.Transform(texts, strings.Fields)
+	if err != nil {
+		t.Fatalf("Transform() 失败: %v", err)
+	}
+	if X.Rows != len(texts) || X.Cols != DefaultNFeatures || X.NNZ() != 4 {
+		t.Errorf("Transform() 得到 (%d, %d) nnz=%d", X.Rows, X.Cols, X.NNZ())
+	}
+
+	if _, err := (&HashingVectorizer{}).TransformWithTokens(nil); err == nil {
+		t.Error("NFeatures 为0时应返回错误")
+	}
+	if _, _, err := (&HashingVectorizer{NFeatures: 4, Norm: matrix.Norm(9)}).TransformRow(nil); err == nil {
+		t.Error("不支持的归一化方式应返回错误")
+	}
+}
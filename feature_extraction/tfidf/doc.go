@@ -0,0 +1,28 @@
+/*
+Package tfidf 实现了TF-IDF加权，这是一个对 sklearn.feature_extraction.text.TfidfTransformer 和 TfidfVectorizer 的 Go 语言实现。
+参考文档：https://scikit-learn.org/1.5/modules/generated/sklearn.feature_extraction.text.TfidfTransformer.html
+
+计算过程：
+
+	idf(t) = ln((1 + n) / (1 + df(t))) + 1   (smooth_idf=True)
+	idf(t) = ln(n / df(t)) + 1               (smooth_idf=False)
+	tfidf(t, d) = tf(t, d) * idf(t)
+
+其中 n 为训练文档数，df(t) 为包含词t的文档数；sublinear_tf=True 时使用 1 + ln(tf) 代替 tf，
+最后按 norm 对每行归一化。所有选项的默认值和计算结果都与 sklearn 一致。
+
+Python 与 Go 实现对比：
+
+Python 版本：
+
+	from sklearn.feature_extraction.text import TfidfVectorizer
+	tv = TfidfVectorizer(analyzer=lambda text: ngram(text, 2), sublinear_tf=True)
+	X = tv.fit_transform(texts)
+
+Go 版本：
+
+	tv := tfidf.NewTfidfVectorizer()
+	tv.Tfidf.SublinearTF = true
+	X, err := tv.FitTransform(texts, func(s string) []string { return ngram.NGram(s, 2) })
+*/
+package tfidf
@@ -0,0 +1,241 @@
+package tfidf
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/yinziyang/mlkit/feature_extraction/count_vectorizer"
+	"github.com/yinziyang/mlkit/matrix"
+	"github.com/yinziyang/mlkit/utils"
+)
+
+// TfidfTransformer 将词频矩阵转换为TF-IDF矩阵
+//
+//	idf(t)  = ln((1 + n) / (1 + df(t))) + 1   (SmoothIDF=true)
+//	idf(t)  = ln(n / df(t)) + 1               (SmoothIDF=false)
+//	tf(t,d) = 1 + ln(tf(t,d))                 (SublinearTF=true，只作用于非零元素)
+//
+// 其中 n 为训练文档数，df(t) 为包含词t的文档数。最终每个元素为 tf*idf，再按 Norm 对每行归一化
+type TfidfTransformer struct {
+	Norm        matrix.Norm // 每行的归一化方式
+	UseIDF      bool        // 是否乘以逆文档频率
+	SmoothIDF   bool        // 是否对文档频率加1平滑，相当于额外有一篇包含所有词的文档，避免除零
+	SublinearTF bool        // 是否使用 1+ln(tf) 代替 tf
+
+	idf []float64 // 每列的逆文档频率，UseIDF 为true时由 Fit 计算
+}
+
+// NewTfidfTransformer 创建TF-IDF转换器
+// 选项的默认值与 sklearn 相同：Norm=L2, UseIDF=true, SmoothIDF=true, SublinearTF=false
+func NewTfidfTransformer() *TfidfTransformer {
+	return &TfidfTransformer{
+		Norm:      matrix.NormL2,
+		UseIDF:    true,
+		SmoothIDF: true,
+	}
+}
+
+// Fit 根据词频矩阵计算每列的逆文档频率
+// 与 sklearn 相同，文档频率为每列存储的元素个数
+func (tt *TfidfTransformer) Fit(X *matrix.SparseMatrix) error {
+	if err := tt.validate(); err != nil {
+		return err
+	}
+	if !tt.UseIDF {
+		tt.idf = nil
+		return nil
+	}
+
+	df := make([]int, X.Cols)
+	for _, col := range X.ColIdx {
+		df[col]++
+	}
+
+	smooth := 0.0
+	if tt.SmoothIDF {
+		smooth = 1
+	}
+	n := float64(X.Rows) + smooth
+	tt.idf = make([]float64, X.Cols)
+	for j, d := range df {
+		tt.idf[j] = math.Log(n/(float64(d)+smooth)) + 1
+	}
+	return nil
+}
+
+// Transform 将词频矩阵转换为TF-IDF矩阵，不修改输入矩阵
+func (tt *TfidfTransformer) Transform(X *matrix.SparseMatrix) (*matrix.SparseMatrix, error) {
+	if err := tt.check(X.Cols); err != nil {
+		return nil, err
+	}
+
+	// SliceRows 会复制数据和特征名
+	result, err := X.SliceRows(0, X.Rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := tt.transform(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FitTransform 组合了Fit和Transform的功能
+func (tt *TfidfTransformer) FitTransform(X *matrix.SparseMatrix) (*matrix.SparseMatrix, error) {
+	if err := tt.Fit(X); err != nil {
+		return nil, err
+	}
+	return tt.Transform(X)
+}
+
+// TransformRow 将词频矩阵的一行（列索引 cols 和词频 data）原地转换为TF-IDF值
+func (tt *TfidfTransformer) TransformRow(cols []int, data []float64) error {
+	if err := tt.check(0); err != nil {
+		return err
+	}
+	row := &matrix.SparseMatrix{
+		Rows:   1,
+		Cols:   len(tt.idf),
+		Data:   data,
+		RowPtr: []int{0, len(data)},
+		ColIdx: cols,
+	}
+	if tt.UseIDF {
+		for _, col := range cols {
+			if col < 0 || col >= len(tt.idf) {
+				return fmt.Errorf("列索引 %d 超出范围 %d", col, len(tt.idf))
+			}
+		}
+	}
+	return tt.transform(row)
+}
+
+// IDF 返回每列的逆文档频率，UseIDF 为false时返回nil
+func (tt *TfidfTransformer) IDF() []float64 {
+	return append([]float64(nil), tt.idf...)
+}
+
+// transform 原地计算TF-IDF并归一化
+func (tt *TfidfTransformer) transform(X *matrix.SparseMatrix) error {
+	if tt.SublinearTF {
+		for k, v := range X.Data {
+			X.Data[k] = math.Log(v) + 1
+		}
+	}
+	if tt.UseIDF {
+		for k, col := range X.ColIdx {
+			X.Data[k] *= tt.idf[col]
+		}
+	}
+	return X.NormalizeRows(tt.Norm)
+}
+
+// check 检查模型是否可以用于转换列数为 cols 的矩阵，cols 为0时不检查列数
+func (tt *TfidfTransformer) check(cols int) error {
+	if err := tt.validate(); err != nil {
+		return err
+	}
+	if !tt.UseIDF {
+		return nil
+	}
+	if tt.idf == nil {
+		return fmt.Errorf("模型尚未训练")
+	}
+	if cols != 0 && cols != len(tt.idf) {
+		return fmt.Errorf("输入矩阵有 %d 列，而模型训练时有 %d 列", cols, len(tt.idf))
+	}
+	return nil
+}
+
+// validate 检查配置是否有效
+func (tt *TfidfTransformer) validate() error {
+	switch tt.Norm {
+	case matrix.NormNone, matrix.NormL1, matrix.NormL2, matrix.NormMax:
+		return nil
+	default:
+		return fmt.Errorf("不支持的归一化方式: %v", tt.Norm)
+	}
+}
+
+// TfidfVectorizer 将分词后的文本转换为TF-IDF矩阵，等价于 CountVectorizer 之后接 TfidfTransformer
+type TfidfVectorizer struct {
+	Counts *count_vectorizer.CountVectorizer // 词汇表和词频统计，可设置 MinDF/MaxDF/MaxFeatures/Binary
+	Tfidf  *TfidfTransformer                 // TF-IDF加权，可设置 Norm/UseIDF/SmoothIDF/SublinearTF
+}
+
+// NewTfidfVectorizer 创建TF-IDF向量化器，所有选项的默认值与 sklearn 相同
+func NewTfidfVectorizer() *TfidfVectorizer {
+	return &TfidfVectorizer{
+		Counts: count_vectorizer.NewCountVectorizer(),
+		Tfidf:  NewTfidfTransformer(),
+	}
+}
+
+// Fit 使用文本数据构建词汇表并计算逆文档频率
+// tokenizer: 分词函数，例如 func(s string) []string { return ngram.NGram(s, 2) }
+func (tv *TfidfVectorizer) Fit(texts []string, tokenizer func(string) []string) error {
+	return tv.FitWithTokens(utils.Tokenize(texts, tokenizer))
+}
+
+// Transform 将文本转换为TF-IDF矩阵
+func (tv *TfidfVectorizer) Transform(texts []string, tokenizer func(string) []string) (*matrix.SparseMatrix, error) {
+	return tv.TransformWithTokens(utils.Tokenize(texts, tokenizer))
+}
+
+// FitTransform 组合了Fit和Transform的功能
+func (tv *TfidfVectorizer) FitTransform(texts []string, tokenizer func(string) []string) (*matrix.SparseMatrix, error) {
+	return tv.FitTransformWithTokens(utils.Tokenize(texts, tokenizer))
+}
+
+// FitWithTokens 使用已分词的文本构建词汇表并计算逆文档频率
+func (tv *TfidfVectorizer) FitWithTokens(tokens [][]string) error {
+	_, err := tv.FitTransformWithTokens(tokens)
+	return err
+}
+
+// TransformWithTokens 将已分词的文本转换为TF-IDF矩阵，矩阵的 FeatureNames 为词汇表
+func (tv *TfidfVectorizer) TransformWithTokens(tokens [][]string) (*matrix.SparseMatrix, error) {
+	X, err := tv.Counts.TransformWithTokens(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if err := tv.Tfidf.check(X.Cols); err != nil {
+		return nil, err
+	}
+	if err := tv.Tfidf.transform(X); err != nil {
+		return nil, err
+	}
+	return X, nil
+}
+
+// FitTransformWithTokens 组合了FitWithTokens和TransformWithTokens的功能，只统计一次词频
+func (tv *TfidfVectorizer) FitTransformWithTokens(tokens [][]string) (*matrix.SparseMatrix, error) {
+	X, err := tv.Counts.FitTransformWithTokens(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if err := tv.Tfidf.Fit(X); err != nil {
+		return nil, err
+	}
+	if err := tv.Tfidf.transform(X); err != nil {
+		return nil, err
+	}
+	return X, nil
+}
+
+// TransformRow 将一篇已分词的文本转换为TF-IDF矩阵的一行，返回非零元素的列索引（升序）和值
+func (tv *TfidfVectorizer) TransformRow(tokens []string) ([]int, []float64, error) {
+	cols, data, err := tv.Counts.TransformRow(tokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := tv.Tfidf.TransformRow(cols, data); err != nil {
+		return nil, nil, err
+	}
+	return cols, data, nil
+}
+
+// GetFeatureNames 返回按列索引排列的词汇表
+func (tv *TfidfVectorizer) GetFeatureNames() []string {
+	return tv.Counts.GetFeatureNames()
+}
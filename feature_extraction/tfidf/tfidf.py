@@ -0,0 +1,32 @@
+from sklearn.feature_extraction.text import TfidfTransformer, TfidfVectorizer
+
+# tfidf_test.go 中的期望值由本脚本生成
+# docs 为 ngram.NGram(text, 2) 对下列文本的分词结果，analyzer 直接返回已分词的列表
+# "我爱北京天安门", "北京的天气很好", "我爱编程", "天安门广场很大", "北京北京我爱北京"
+docs = [
+    ["我爱", "爱北", "北京", "京天", "天安", "安门"],
+    ["北京", "京的", "的天", "天气", "气很", "很好"],
+    ["我爱", "爱编", "编程"],
+    ["天安", "安门", "门广", "广场", "场很", "很大"],
+    ["北京", "京北", "北京", "京我", "我爱", "爱北", "北京"],
+]
+
+cases = [
+    dict(),
+    dict(smooth_idf=False, sublinear_tf=True, norm=None),
+    dict(use_idf=False, sublinear_tf=True, norm="l1"),
+]
+for params in cases:
+    tv = TfidfVectorizer(analyzer=lambda doc: doc, **params)
+    X = tv.fit_transform(docs)
+    print(f"\n=== {params} ===")
+    print("vocabulary:", tv.get_feature_names_out().tolist())
+    if tv.use_idf:
+        print("idf:", [repr(v) for v in tv.idf_])
+    for i in range(X.shape[0]):
+        row = X.getrow(i)
+        print(list(zip(row.indices.tolist(), [repr(v) for v in row.data])))
+
+# TfidfTransformer 直接作用于词频矩阵，结果与 TfidfVectorizer 相同
+counts = TfidfVectorizer(analyzer=lambda doc: doc, use_idf=False, norm=None).fit_transform(docs)
+print("\nTfidfTransformer:", TfidfTransformer().fit_transform(counts).toarray())
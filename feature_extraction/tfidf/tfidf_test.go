@@ -0,0 +1,192 @@
+package tfidf
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/yinziyang/mlkit/matrix"
+	"github.com/yinziyang/mlkit/ngram"
+)
+
+var testTexts = []string{"我爱北京天安门", "北京的天气很好", "我爱编程", "天安门广场很大", "北京北京我爱北京"}
+
+func bigram(s string) []string {
+	return ngram.NGram(s, 2)
+}
+
+type entry struct {
+	col int
+	v   float64
+}
+
+func checkRows(t *testing.T, X *matrix.SparseMatrix, want [][]entry) {
+	t.Helper()
+	if X.Rows != len(want) {
+		t.Fatalf("行数不匹配: 期望 %d, 得到 %d", len(want), X.Rows)
+	}
+	for i, row := range want {
+		cols, data := X.Row(i)
+		if len(cols) != len(row) {
+			t.Errorf("第%d行: 得到 %v %v, 期望 %v", i, cols, data, row)
+			continue
+		}
+		for k, e := range row {
+			if cols[k] != e.col || math.Abs(data[k]-e.v) > 1e-12 {
+				t.Errorf("第%d行: 得到 %v %v, 期望 %v", i, cols, data, row)
+				break
+			}
+		}
+	}
+}
+
+// TestTfidfVectorizer 的期望值由 tfidf.py 生成
+func TestTfidfVectorizer(t *testing.T) {
+	wantVocab := []string{"京北", "京天", "京我", "京的", "北京", "场很", "天安", "天气", "安门", "广场", "很大", "很好", "我爱", "气很", "爱北", "爱编", "的天", "编程", "门广"}
+	const (
+		a = 2.09861228866811   // 平滑后 df=1 的 idf
+		b = 1.6931471805599454 // 平滑后 df=2 的 idf
+		c = 1.4054651081081644 // 平滑后 df=3 的 idf
+		d = 2.6094379124341005 // 未平滑 df=1 的 idf
+		e = 1.916290731874155  // 未平滑 df=2 的 idf
+		f = 1.5108256237659907 // 未平滑 df=3 的 idf
+	)
+
+	tests := []struct {
+		name    string
+		setup   func(tt *TfidfTransformer)
+		wantIDF []float64
+		want    [][]entry
+	}{
+		{
+			name:    "默认",
+			setup:   func(tt *TfidfTransformer) {},
+			wantIDF: []float64{a, a, a, a, c, a, b, a, b, a, a, a, c, a, b, a, a, a, a},
+			want: [][]entry{
+				{{1, 0.5096620419846487}, {4, 0.34132660940015347}, {6, 0.41119212638004476}, {8, 0.41119212638004476}, {12, 0.34132660940015347}, {14, 0.41119212638004476}},
+				{{3, 0.42841135808141123}, {4, 0.28691207944979275}, {7, 0.42841135808141123}, {11, 0.42841135808141123}, {13, 0.42841135808141123}, {16, 0.42841135808141123}},
+				{{12, 0.42799292268317357}, {15, 0.6390704413963749}, {17, 0.6390704413963749}},
+				{{5, 0.43429718303084847}, {6, 0.3503882327118585}, {8, 0.3503882327118585}, {9, 0.43429718303084847}, {10, 0.43429718303084847}, {18, 0.43429718303084847}},
+				{{0, 0.37434407533681635}, {2, 0.37434407533681635}, {4, 0.752107770197254}, {12, 0.25070259006575135}, {14, 0.3020184429197762}},
+			},
+		},
+		{
+			name: "不平滑+亚线性+不归一化",
+			setup: func(tt *TfidfTransformer) {
+				tt.SmoothIDF, tt.SublinearTF, tt.Norm = false, true, matrix.NormNone
+			},
+			wantIDF: []float64{d, d, d, d, f, d, e, d, e, d, d, d, f, d, e, d, d, d, d},
+			want: [][]entry{
+				{{1, d}, {4, f}, {6, e}, {8, e}, {12, f}, {14, e}},
+				{{3, d}, {4, f}, {7, d}, {11, d}, {13, d}, {16, d}},
+				{{12, f}, {15, d}, {17, d}},
+				{{5, d}, {6, e}, {8, e}, {9, d}, {10, d}, {18, d}},
+				{{0, d}, {2, d}, {4, 3.170637220069971}, {12, f}, {14, e}},
+			},
+		},
+		{
+			name: "不使用idf+亚线性+L1",
+			setup: func(tt *TfidfTransformer) {
+				tt.UseIDF, tt.SublinearTF, tt.Norm = false, true, matrix.NormL1
+			},
+			want: [][]entry{
+				{{1, 1.0 / 6}, {4, 1.0 / 6}, {6, 1.0 / 6}, {8, 1.0 / 6}, {12, 1.0 / 6}, {14, 1.0 / 6}},
+				{{3, 1.0 / 6}, {4, 1.0 / 6}, {7, 1.0 / 6}, {11, 1.0 / 6}, {13, 1.0 / 6}, {16, 1.0 / 6}},
+				{{12, 1.0 / 3}, {15, 1.0 / 3}, {17, 1.0 / 3}},
+				{{5, 1.0 / 6}, {6, 1.0 / 6}, {8, 1.0 / 6}, {9, 1.0 / 6}, {10, 1.0 / 6}, {18, 1.0 / 6}},
+				{{0, 0.1639717287583783}, {2, 0.1639717287583783}, {4, 0.34411308496648685}, {12, 0.1639717287583783}, {14, 0.1639717287583783}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tv := NewTfidfVectorizer()
+			tt.setup(tv.Tfidf)
+			X, err := tv.FitTransform(testTexts, bigram)
+			if err != nil {
+				t.Fatalf("FitTransform() 失败: %v", err)
+			}
+			if !reflect.DeepEqual(tv.GetFeatureNames(), wantVocab) || !reflect.DeepEqual(X.FeatureNames, wantVocab) {
+				t.Fatalf("词汇表不匹配:\n期望 %v\n得到 %v", wantVocab, tv.GetFeatureNames())
+			}
+
+			idf := tv.Tfidf.IDF()
+			if len(idf) != len(tt.wantIDF) {
+				t.Fatalf("idf 长度不匹配: 期望 %d, 得到 %d", len(tt.wantIDF), len(idf))
+			}
+			for j := range idf {
+				if math.Abs(idf[j]-tt.wantIDF[j]) > 1e-12 {
+					t.Errorf("第%d列的 idf 不匹配: 期望 %v, 得到 %v", j, tt.wantIDF[j], idf[j])
+				}
+			}
+			checkRows(t, X, tt.want)
+
+			// Transform 和逐行转换的结果与 FitTransform 相同
+			X2, err := tv.Transform(testTexts, bigram)
+			if err != nil {
+				t.Fatalf("Transform() 失败: %v", err)
+			}
+			checkRows(t, X2, tt.want)
+			for i, text := range testTexts {
+				cols, data, err := tv.TransformRow(bigram(text))
+				if err != nil {
+					t.Fatalf("TransformRow() 失败: %v", err)
+				}
+				wantCols, wantData := X.Row(i)
+				if !reflect.DeepEqual(cols, wantCols) || !reflect.DeepEqual(data, wantData) {
+					t.Errorf("第%d行: TransformRow() = %v %v, 期望 %v %v", i, cols, data, wantCols, wantData)
+				}
+			}
+		})
+	}
+}
+
+func TestTfidfTransformer(t *testing.T) {
+	counts := matrix.DenseToSparse([][]float64{
+		{3, 0, 1},
+		{2, 0, 0},
+		{3, 0, 0},
+		{4, 0, 0},
+		{3, 2, 0},
+		{3, 0, 2},
+	})
+
+	// sklearn 文档中 TfidfTransformer 的示例: TfidfTransformer(smooth_idf=False)
+	tt := NewTfidfTransformer()
+	tt.SmoothIDF = false
+	X, err := tt.FitTransform(counts)
+	if err != nil {
+		t.Fatalf("FitTransform() 失败: %v", err)
+	}
+	want := [][]float64{
+		{0.81940995, 0, 0.57320793},
+		{1, 0, 0},
+		{1, 0, 0},
+		{1, 0, 0},
+		{0.47330339, 0.88089948, 0},
+		{0.58149261, 0, 0.81355169},
+	}
+	for i, row := range want {
+		for j, v := range row {
+			if got := X.At(i, j); math.Abs(got-v) > 1e-8 {
+				t.Errorf("(%d, %d) 不匹配: 期望 %v, 得到 %v", i, j, v, got)
+			}
+		}
+	}
+	if counts.At(0, 0) != 3 {
+		t.Error("Transform() 不应修改输入矩阵")
+	}
+
+	if _, err := tt.Transform(matrix.DenseToSparse([][]float64{{1, 2}})); err == nil {
+		t.Error("列数不匹配时应返回错误")
+	}
+	if _, err := NewTfidfTransformer().Transform(counts); err == nil {
+		t.Error("未训练时应返回错误")
+	}
+	bad := NewTfidfTransformer()
+	bad.Norm = matrix.Norm(9)
+	if err := bad.Fit(counts); err == nil {
+		t.Error("不支持的归一化方式应返回错误")
+	}
+}
@@ -17,14 +17,19 @@ import (
 // InfoGain 实现了基于信息增益的特征选择算法
 // 对于每个类别，选择信息增益最高的N个特征
 type InfoGain struct {
-	maxFeatures    int                       // 每个类别的最大特征数，如果为0或负数则保留所有特征
-	vocab          map[string]bool           // 词汇表，保存所有选中的特征
-	features       []string                  // 已排序的特征列表
-	featureToIndex map[string]int32          // 特征到索引的映射
-	scores         map[string]float64        // 特征的信息增益分数
-	numFeatures    int                       // 特征总数
-	featureInLabel map[string]map[string]int // 特征在每个类别中的出现次数
-	targets        []string                  // 标签列表(去重)
+	maxFeatures    int                             // 每个类别的最大特征数，如果为0或负数则保留所有特征
+	vocab          map[string]bool                 // 词汇表，保存所有选中的特征
+	features       []string                        // 已排序的特征列表
+	featureToIndex map[string]int32                // 特征到索引的映射
+	scores         map[string]float64              // 特征的信息增益分数
+	numFeatures    int                             // 特征总数
+	featureInLabel map[string]map[string]int       // 特征在每个类别中的出现次数
+	featureFreq    map[string]int                  // 包含每个特征的文档数
+	targetFreq     map[string]int                  // 每个类别的文档数
+	totalDocs      int                             // 已统计的文档总数
+	targets        []string                        // 标签列表(去重并按字典序排列)
+	scorer         Scorer                          // 特征的打分函数
+	classRankings  map[string][]utils.FeatureScore // 每个标签下与其正相关的特征，按标签条件分数排列
 }
 
 // NewInfoGain 创建信息增益模型
@@ -33,10 +38,17 @@ type InfoGain struct {
 // - 如果设置为0或负数，则保留所有特征
 // - 如果不设置，则默认保留所有特征
 func NewInfoGain(maxFeatures ...int) *InfoGain {
+	return NewInfoGainWithScorer(MutualInformation{}, maxFeatures...)
+}
+
+// NewInfoGainWithScorer 创建使用指定打分函数的特征选择模型
+// scorer 可以是 MutualInformation、ChiSquare、OddsRatio、Gini、BiNormalSeparation、DocumentFrequency
+// 或自定义的 Scorer，maxFeatures 的含义与 NewInfoGain 相同
+func NewInfoGainWithScorer(scorer Scorer, maxFeatures ...int) *InfoGain {
 	ig := &InfoGain{
-		// tokenizer: tokenizer,
 		vocab:  make(map[string]bool),
 		scores: make(map[string]float64),
+		scorer: scorer,
 	}
 
 	if len(maxFeatures) > 0 {
@@ -46,19 +58,20 @@ func NewInfoGain(maxFeatures ...int) *InfoGain {
 	return ig
 }
 
+// Scorer 返回模型使用的打分函数
+func (ig *InfoGain) Scorer() Scorer {
+	if ig.scorer == nil {
+		return MutualInformation{}
+	}
+	return ig.scorer
+}
+
 // Fit 使用文本数据和对应的标签训练模型
 // texts: 输入的文本列表
 // targets: 对应的标签列表
 // tokenizer: 分词函数，用于将文本转换为词列表
 func (ig *InfoGain) Fit(texts []string, targets []string, tokenizer func(string) []string) {
-	// 先对所有文本进行分词
-	tokens := make([][]string, len(texts))
-	for i, text := range texts {
-		tokens[i] = tokenizer(text)
-	}
-
-	// 调用FitWithTokens
-	ig.FitWithTokens(tokens, targets)
+	ig.FitWithTokens(utils.Tokenize(texts, tokenizer), targets)
 }
 
 // Transform 将文本转换为特征矩阵
@@ -69,11 +82,7 @@ func (ig *InfoGain) Fit(texts []string, targets []string, tokenizer func(string)
 // - 稀疏矩阵表示的特征矩阵
 // - 特征名列表
 func (ig *InfoGain) Transform(texts []string, normalize bool, tokenizer func(string) []string) (*matrix.SparseMatrix, []string) {
-	tokens := make([][]string, len(texts))
-	for i, text := range texts {
-		tokens[i] = tokenizer(text)
-	}
-	return ig.TransformWithTokens(tokens, normalize)
+	return ig.TransformWithTokens(utils.Tokenize(texts, tokenizer), normalize)
 }
 
 // FitTransform 组合了Fit和Transform的功能
@@ -86,27 +95,208 @@ func (ig *InfoGain) FitTransform(texts []string, targets []string, normalize boo
 // FitWithTokens 使用已分词的文本数据训练模型
 // tokens: 已分词的文本列表，每个文本是一个词列表
 // targets: 对应的标签列表
+//
+// 每个特征除了全局分数外，还会对每个与其正相关（P(y|f) > P(y)）的标签计算标签条件分数，
+// 例如一对其余的信息增益（见 ClassScorer）。设置了 maxFeatures 时，每个标签按标签条件分数
+// 从高到低选择前 maxFeatures 个特征，分数相同时按特征名排序，因此选择结果是确定的；
+// 打分函数没有实现 ClassScorer 或只有一个标签时，按全局分数选择前 maxFeatures 个特征
+//
+// 训练结果与 GOMAXPROCS 和协程的调度顺序无关：计数为整数，每个特征的分数按排序后的标签顺序求和，
+// 相同的输入总是选出相同的特征，Save 写出的文件也逐字节相同
+//
+// tokens 与 targets 的长度不一致时会 panic，需要返回错误时使用 PartialFit 和 Finalize
 func (ig *InfoGain) FitWithTokens(tokens [][]string, targets []string) {
-	// 统计标签频率
-	targetFreq := make(map[string]int)
-	for _, target := range targets {
-		if _, ok := targetFreq[target]; !ok {
-			ig.targets = append(ig.targets, target)
-		}
-		targetFreq[target]++
+	ig.resetCounts()
+	if err := ig.countFeatures(tokens, targets); err != nil {
+		panic(fmt.Sprintf("infogain: %v", err))
 	}
+	ig.finalize()
+}
 
-	// 计算标签熵
-	totalDocs := float64(len(targets))
-	labelEntropy := 0.0
-	for _, freq := range targetFreq {
-		p := float64(freq) / totalDocs
-		labelEntropy -= p * math.Log2(p)
+// PartialFit 统计一批已分词的文本，累加到之前批次的计数上，用于无法一次性放入内存的语料
+// 所有批次统计完成后调用 Finalize 计算分数并选择特征，结果与对所有文本调用 FitWithTokens 相同。
+// 计数可以通过 SaveCheckpoint 保存，之后用 LoadCheckpoint 恢复并继续统计
+func (ig *InfoGain) PartialFit(tokens [][]string, targets []string) error {
+	if ig.targetFreq == nil {
+		ig.resetCounts()
 	}
+	return ig.countFeatures(tokens, targets)
+}
 
-	// 统计每个特征在每个标签中的频率
+// Finalize 根据 PartialFit 累计的计数计算分数并选择特征
+// 计数会被保留，之后可以继续调用 PartialFit 并再次 Finalize
+func (ig *InfoGain) Finalize() error {
+	if ig.totalDocs == 0 {
+		return fmt.Errorf("没有统计任何文档，请先调用 PartialFit")
+	}
+	ig.finalize()
+	return nil
+}
+
+// NumDocs 返回 PartialFit 或 FitWithTokens 已统计的文档总数，可用于从检查点恢复时跳过已处理的数据
+func (ig *InfoGain) NumDocs() int {
+	return ig.totalDocs
+}
+
+// resetCounts 清空累计的计数
+func (ig *InfoGain) resetCounts() {
 	ig.featureInLabel = make(map[string]map[string]int)
-	featureFreq := make(map[string]int)
+	ig.featureFreq = make(map[string]int)
+	ig.targetFreq = make(map[string]int)
+	ig.totalDocs = 0
+}
+
+// finalize 根据累计的计数计算每个特征的分数，并选择特征
+func (ig *InfoGain) finalize() {
+	// 标签按字典序排列
+	ig.targets = make([]string, 0, len(ig.targetFreq))
+	for target := range ig.targetFreq {
+		ig.targets = append(ig.targets, target)
+	}
+	sort.Strings(ig.targets)
+
+	featureFreq := ig.featureFreq
+	targetFreq := ig.targetFreq
+
+	// 并发计算特征的全局分数和标签条件分数
+	type featureScore struct {
+		feature     string
+		score       float64
+		classScores map[string]float64 // 与特征正相关的标签的条件分数
+	}
+	numWorkers := runtime.GOMAXPROCS(0)
+	scorer := ig.Scorer()
+	scoresChan := make(chan featureScore, len(featureFreq))
+	semaphore := make(chan struct{}, numWorkers)
+
+	for feature, freq := range featureFreq {
+		semaphore <- struct{}{}
+		go func(feature string, freq int) {
+			defer func() { <-semaphore }()
+
+			stats := &FeatureStats{
+				FeatureCount:   freq,
+				FeatureInLabel: ig.featureInLabel[feature],
+				TargetFreq:     targetFreq,
+				Targets:        ig.targets,
+				TotalDocs:      ig.totalDocs,
+			}
+			classScores := make(map[string]float64)
+			for _, target := range ig.targets {
+				if stats.positivelyAssociated(target) {
+					classScores[target] = classScore(scorer, stats, target)
+				}
+			}
+
+			scoresChan <- featureScore{feature, scorer.Score(stats), classScores}
+		}(feature, freq)
+	}
+
+	// 收集结果
+	ig.scores = make(map[string]float64, len(featureFreq))
+	classRankings := make(map[string][]utils.FeatureScore, len(ig.targets))
+	for i := 0; i < len(featureFreq); i++ {
+		score := <-scoresChan
+		ig.scores[score.feature] = score.score
+		for target, s := range score.classScores {
+			classRankings[target] = append(classRankings[target], utils.FeatureScore{Feature: score.feature, Score: s})
+		}
+	}
+	for _, ranking := range classRankings {
+		sortFeatureScores(ranking)
+	}
+
+	// 选择特征：打分函数提供标签条件分数且至少有两个标签时按标签选择，否则按全局分数选择
+	if ig.maxFeatures > 0 {
+		selected := make(map[string]float64)
+		if _, ok := scorer.(ClassScorer); ok && len(ig.targets) > 1 {
+			for _, target := range ig.targets {
+				ranking := classRankings[target]
+				if len(ranking) > ig.maxFeatures {
+					ranking = ranking[:ig.maxFeatures]
+				}
+				for _, fs := range ranking {
+					selected[fs.Feature] = ig.scores[fs.Feature]
+				}
+			}
+		} else {
+			ranking := make([]utils.FeatureScore, 0, len(ig.scores))
+			for feature, score := range ig.scores {
+				ranking = append(ranking, utils.FeatureScore{Feature: feature, Score: score})
+			}
+			sortFeatureScores(ranking)
+			if len(ranking) > ig.maxFeatures {
+				ranking = ranking[:ig.maxFeatures]
+			}
+			for _, fs := range ranking {
+				selected[fs.Feature] = fs.Score
+			}
+		}
+		ig.scores = selected
+
+		// 标签排名中只保留选中的特征
+		for target, ranking := range classRankings {
+			kept := ranking[:0]
+			for _, fs := range ranking {
+				if _, ok := selected[fs.Feature]; ok {
+					kept = append(kept, fs)
+				}
+			}
+			classRankings[target] = kept
+		}
+	}
+	ig.classRankings = classRankings
+
+	ig.features = make([]string, 0, len(ig.scores))
+	for feature := range ig.scores {
+		ig.features = append(ig.features, feature)
+	}
+	ig.setFeatures()
+}
+
+// setFeatures 根据 ig.features 重建词汇表和特征索引
+func (ig *InfoGain) setFeatures() {
+	sort.Strings(ig.features)
+	ig.numFeatures = len(ig.features)
+	ig.vocab = make(map[string]bool, len(ig.features))
+	ig.featureToIndex = make(map[string]int32, len(ig.features))
+	for i, feature := range ig.features {
+		ig.vocab[feature] = true
+		ig.featureToIndex[feature] = int32(i)
+	}
+}
+
+// TopFeaturesForClass 返回与标签 label 正相关、标签条件分数最高的前k个特征，按分数从高到低排列，
+// 分数相同时按特征名排序；k小于等于0时返回该标签的所有特征。只包含模型选中的特征，标签不存在时返回nil
+func (ig *InfoGain) TopFeaturesForClass(label string, k int) []utils.FeatureScore {
+	ranking := ig.classRankings[label]
+	if k > 0 && len(ranking) > k {
+		ranking = ranking[:k]
+	}
+	if ranking == nil {
+		return nil
+	}
+	return append([]utils.FeatureScore{}, ranking...)
+}
+
+// GetTargets 返回按字典序排列的标签列表
+func (ig *InfoGain) GetTargets() []string {
+	return append([]string(nil), ig.targets...)
+}
+
+// countFeatures 并发统计每个特征出现的文档数(ig.featureFreq)、在每个标签中出现的文档数(ig.featureInLabel)
+// 以及每个标签的文档数(ig.targetFreq)，累加到已有的计数上；输入无效时返回错误，计数保持不变
+func (ig *InfoGain) countFeatures(tokens [][]string, targets []string) error {
+	if len(tokens) != len(targets) {
+		return fmt.Errorf("文本数量(%d)与标签数量(%d)不一致", len(tokens), len(targets))
+	}
+
+	for _, target := range targets {
+		ig.targetFreq[target]++
+	}
+	ig.totalDocs += len(targets)
+
+	featureFreq := ig.featureFreq
 	var mutex sync.Mutex
 
 	// 并发处理文档
@@ -158,93 +348,7 @@ func (ig *InfoGain) FitWithTokens(tokens [][]string, targets []string) {
 		}(start, end)
 	}
 	wg.Wait()
-
-	// 并发计算信息增益
-	type featureScore struct {
-		feature string
-		score   float64
-	}
-	scoresChan := make(chan featureScore, len(featureFreq))
-	semaphore := make(chan struct{}, numWorkers)
-
-	for feature, freq := range featureFreq {
-		semaphore <- struct{}{}
-		go func(feature string, freq int) {
-			defer func() { <-semaphore }()
-
-			// 计算条件熵
-			conditionalEntropy := calculateFeatureEntropy(ig.featureInLabel[feature], targetFreq, totalDocs, float64(freq))
-
-			// 计算信息增益
-			informationGain := labelEntropy - conditionalEntropy
-
-			scoresChan <- featureScore{feature, informationGain}
-		}(feature, freq)
-	}
-
-	// 收集结果
-	ig.scores = make(map[string]float64)
-	for i := 0; i < len(featureFreq); i++ {
-		score := <-scoresChan
-		ig.scores[score.feature] = score.score
-	}
-
-	// 选择特征
-	var scoreSlice []utils.FeatureScore
-	for feature, score := range ig.scores {
-		scoreSlice = append(scoreSlice, utils.FeatureScore{Feature: feature, Score: score})
-	}
-
-	// 按分数排序
-	sort.Slice(scoreSlice, func(i, j int) bool {
-		if scoreSlice[i].Score == scoreSlice[j].Score {
-			return scoreSlice[i].Feature < scoreSlice[j].Feature
-		}
-		return scoreSlice[i].Score > scoreSlice[j].Score
-	})
-
-	// 选择特征
-	if ig.maxFeatures > 0 {
-		ig.featureToIndex = make(map[string]int32, ig.maxFeatures)
-		ig.features = make([]string, 0, ig.maxFeatures)
-	} else {
-		ig.featureToIndex = make(map[string]int32, len(scoreSlice))
-		ig.features = make([]string, 0, len(scoreSlice))
-	}
-
-	newScores := make(map[string]float64)
-
-	var labelFeatureStat = make(map[string]int)
-	for i := 0; i < len(scoreSlice); i++ {
-		feature := scoreSlice[i].Feature
-		if ig.maxFeatures > 0 {
-			for target := range ig.featureInLabel[feature] {
-				if labelFeatureStat[target] < ig.maxFeatures {
-					ig.features = append(ig.features, feature)
-					newScores[feature] = scoreSlice[i].Score
-					labelFeatureStat[target]++
-					break
-				}
-			}
-		} else {
-			ig.features = append(ig.features, feature)
-			newScores[feature] = scoreSlice[i].Score
-		}
-	}
-	// 更新词汇表
-	ig.vocab = make(map[string]bool, len(ig.features))
-	ig.scores = newScores
-
-	for _, feature := range ig.features {
-		ig.vocab[feature] = true
-	}
-
-	sort.Strings(ig.features)
-	ig.numFeatures = len(ig.features)
-
-	for i, feature := range ig.features {
-		ig.featureToIndex[feature] = int32(i)
-	}
+	return nil
 }
 
 func (ig *InfoGain) TransformWithToken(token []string, normalize bool) (*matrix.SparseMatrix, []string) {
@@ -256,8 +360,10 @@ func (ig *InfoGain) TransformWithToken(token []string, normalize bool) (*matrix.
 // tokens: 已分词的文本列表
 // normalize: 是否对特征值进行L2归一化
 // 返回值:
-// - 稀疏矩阵表示的特征矩阵
+// - 稀疏矩阵表示的特征矩阵，其 FeatureNames 为对应的特征名
 // - 特征名列表
+//
+// 矩阵的第i行对应 tokens[i]，行内按列索引排列，结果与协程的调度顺序无关
 func (ig *InfoGain) TransformWithTokens(tokens [][]string, normalize bool) (*matrix.SparseMatrix, []string) {
 	numWorkers := runtime.NumCPU()
 	rows := len(tokens)
@@ -271,7 +377,6 @@ func (ig *InfoGain) TransformWithTokens(tokens [][]string, normalize bool) (*mat
 	type transformResult struct {
 		docIdx     int
 		nonZeros   []float64
-		rowIndices []int
 		colIndices []int
 	}
 
@@ -285,58 +390,11 @@ func (ig *InfoGain) TransformWithTokens(tokens [][]string, normalize bool) (*mat
 		go func() {
 			defer wg.Done()
 			for task := range taskChan {
-				words := task.words
-				wordPresent := make(map[string]bool)
-				for _, word := range words {
-					wordPresent[word] = true
-				}
-
-				// 计算特征值
-				docScores := make(map[string]float64)
-				for _, feature := range ig.features {
-					if wordPresent[feature] {
-						docScores[feature] = ig.scores[feature]
-					}
-				}
-
-				// L2归一化
-				if normalize {
-					var norm float64
-					for _, score := range docScores {
-						norm += score * score
-					}
-					norm = math.Sqrt(norm)
-					if norm > 0 {
-						for feature := range docScores {
-							docScores[feature] /= norm
-						}
-					}
-				}
-
-				// 构建局部稀疏矩阵数据
-				var nonZeros []float64
-				var rowIndices []int
-				var colIndices []int
-
-				for feature, score := range docScores {
-					if score != 0 {
-						nonZeros = append(nonZeros, score)
-						rowIndices = append(rowIndices, task.docIdx)
-						colIndices = append(colIndices, int(ig.featureToIndex[feature]))
-					}
-				}
-				// for j, feature := range ig.features {
-				// 	if score := docScores[feature]; score > 0 {
-				// 		nonZeros = append(nonZeros, score)
-				// 		rowIndices = append(rowIndices, task.docIdx)
-				// 		colIndices = append(colIndices, j)
-				// 	}
-				// }
+				colIndices, nonZeros := ig.transformRow(task.words)
 
 				resultChan <- transformResult{
 					docIdx:     task.docIdx,
 					nonZeros:   nonZeros,
-					rowIndices: rowIndices,
 					colIndices: colIndices,
 				}
 			}
@@ -356,25 +414,101 @@ func (ig *InfoGain) TransformWithTokens(tokens [][]string, normalize bool) (*mat
 		close(resultChan)
 	}()
 
-	// 收集结果
-	var allNonZeros []float64
-	var allRowIndices []int
-	var allColIndices []int
-
+	// 按文档下标收集结果，与协程完成的顺序无关
+	results := make([]transformResult, rows)
 	for result := range resultChan {
-		allNonZeros = append(allNonZeros, result.nonZeros...)
-		allRowIndices = append(allRowIndices, result.rowIndices...)
-		allColIndices = append(allColIndices, result.colIndices...)
+		results[result.docIdx] = result
+	}
+
+	// 按文档顺序拼接为CSR矩阵，每行的列索引已经有序
+	X := matrix.NewSparseMatrix(0, cols)
+	for _, result := range results {
+		X.ColIdx = append(X.ColIdx, result.colIndices...)
+		X.Data = append(X.Data, result.nonZeros...)
+		X.RowPtr = append(X.RowPtr, len(X.Data))
+		X.Rows++
+	}
+	X.FeatureNames = append([]string(nil), ig.features...)
+
+	// L2归一化
+	if normalize {
+		X.NormalizeRows(matrix.NormL2)
+	}
+
+	return X, ig.features
+}
+
+// TransformRow 将一篇已分词的文本转换为特征矩阵的一行
+// 返回该行非零特征的列索引（升序）和值，与 TransformWithTokens 结果中对应的行相同，
+// 可以配合 matrix.ChunkedBuilder 逐行构建无法一次性放入内存的特征矩阵
+func (ig *InfoGain) TransformRow(tokens []string, normalize bool) ([]int, []float64) {
+	cols, data := ig.transformRow(tokens)
+	if normalize {
+		row := &matrix.SparseMatrix{
+			Rows:   1,
+			Cols:   ig.numFeatures,
+			Data:   data,
+			RowPtr: []int{0, len(data)},
+			ColIdx: cols,
+		}
+		row.NormalizeRows(matrix.NormL2)
+	}
+	return cols, data
+}
+
+// TransformChunked 从 tokens 中依次读取已分词的文本并转换为分块特征矩阵，直到通道关闭
+// 每次只在内存中保留一个块，opts 的含义见 matrix.ChunkedOptions，未设置特征名时使用模型的特征列表。
+// 出错时会删除已溢写的临时文件，并继续读取、丢弃 tokens 中剩余的数据直到通道关闭，发送方不会被阻塞
+func (ig *InfoGain) TransformChunked(tokens <-chan []string, normalize bool, opts matrix.ChunkedOptions) (*matrix.ChunkedMatrix, error) {
+	if opts.FeatureNames == nil {
+		opts.FeatureNames = ig.features
+	}
+	builder, err := matrix.NewChunkedBuilder(ig.numFeatures, opts)
+	if err != nil {
+		drain(tokens)
+		return nil, err
+	}
+	for words := range tokens {
+		cols, data := ig.TransformRow(words, normalize)
+		if err := builder.AppendRow(cols, data); err != nil {
+			builder.Abort()
+			drain(tokens)
+			return nil, err
+		}
+	}
+	cm, err := builder.Finish()
+	if err != nil {
+		builder.Abort()
+		return nil, err
 	}
+	return cm, nil
+}
+
+// drain 读取并丢弃通道中剩余的数据，直到通道关闭
+func drain(tokens <-chan []string) {
+	for range tokens {
+	}
+}
+
+// transformRow 返回文本中出现的、分数不为0的特征的列索引（升序）和分数
+func (ig *InfoGain) transformRow(tokens []string) ([]int, []float64) {
+	var cols []int
+	seen := make(map[int32]bool)
+	for _, word := range tokens {
+		idx, ok := ig.featureToIndex[word]
+		if !ok || seen[idx] || ig.scores[word] == 0 {
+			continue
+		}
+		seen[idx] = true
+		cols = append(cols, int(idx))
+	}
+	sort.Ints(cols)
 
-	// 修改返回值部分
-	return &matrix.SparseMatrix{
-		Rows:   rows,
-		Cols:   cols,
-		Data:   allNonZeros,
-		RowIdx: allRowIndices,
-		ColIdx: allColIndices,
-	}, ig.features
+	data := make([]float64, len(cols))
+	for k, col := range cols {
+		data[k] = ig.scores[ig.features[col]]
+	}
+	return cols, data
 }
 
 // FitTransformWithTokens 组合了FitWithTokens和TransformWithTokens的功能
@@ -387,10 +521,11 @@ func (ig *InfoGain) FitTransformWithTokens(tokens [][]string, targets []string,
 // calculateFeatureEntropy 计算特征的条件熵
 // featureLabelFreq: 特征在每个标签中的频率
 // targetFreq: 每个标签的频率
+// targets: 按字典序排列的标签，按此顺序求和
 // totalDocs: 文档总数
 // featureCount: 特征出现的总次数
 // 返回值: 特征的条件熵
-func calculateFeatureEntropy(featureLabelFreq map[string]int, targetFreq map[string]int, totalDocs float64, featureCount float64) float64 {
+func calculateFeatureEntropy(featureLabelFreq map[string]int, targetFreq map[string]int, targets []string, totalDocs float64, featureCount float64) float64 {
 	// 特征出现时的条件熵
 	entropyPresent := 0.0
 	// 特征不出现时的条件熵
@@ -400,7 +535,8 @@ func calculateFeatureEntropy(featureLabelFreq map[string]int, targetFreq map[str
 	pFeaturePresent := featureCount / totalDocs
 
 	// 对每个标签计算：
-	for target, labelCount := range targetFreq {
+	for _, target := range targets {
+		labelCount := targetFreq[target]
 		// 特征在该标签中出现的次数
 		featureInLabelCount := float64(featureLabelFreq[target])
 
@@ -454,9 +590,19 @@ func (ig *InfoGain) Save(filename string) error {
 		FeatureToIndex: ig.featureToIndex,
 		Scores:         ig.scores,
 		NumFeatures:    int32(ig.numFeatures),
+		Scorer:         ig.Scorer().Name(),
+	}
+	for _, target := range ig.targets {
+		ranking := &infogainpb.ClassRanking{Label: target}
+		for _, fs := range ig.classRankings[target] {
+			ranking.Features = append(ranking.Features, fs.Feature)
+			ranking.Scores = append(ranking.Scores, fs.Score)
+		}
+		model.ClassRankings = append(model.ClassRankings, ranking)
 	}
 
-	data, err := proto.Marshal(model)
+	// map 字段按键排序后序列化，相同的模型总是得到相同的文件内容
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(model)
 	if err != nil {
 		return fmt.Errorf("无法序列化模型: %v", err)
 	}
@@ -478,6 +624,12 @@ func (ig *InfoGain) Load(filename string) error {
 		return fmt.Errorf("无法反序列化模型: %v", err)
 	}
 
+	scorer, err := ScorerByName(model.GetScorer())
+	if err != nil {
+		return err
+	}
+
+	ig.scorer = scorer
 	ig.maxFeatures = int(model.GetMaxFeatures())
 	ig.scores = model.GetScores()
 	ig.featureToIndex = model.GetFeatureToIndex()
@@ -487,12 +639,7 @@ func (ig *InfoGain) Load(filename string) error {
 	for word, score := range ig.scores {
 		scoreSlice = append(scoreSlice, utils.FeatureScore{Feature: word, Score: score})
 	}
-	sort.Slice(scoreSlice, func(i, j int) bool {
-		if scoreSlice[i].Score == scoreSlice[j].Score {
-			return scoreSlice[i].Feature < scoreSlice[j].Feature
-		}
-		return scoreSlice[i].Score > scoreSlice[j].Score
-	})
+	sortFeatureScores(scoreSlice)
 
 	// 重建词汇表和特征列表
 	ig.vocab = make(map[string]bool)
@@ -509,5 +656,113 @@ func (ig *InfoGain) Load(filename string) error {
 		panic(fmt.Sprintf("infogain %d != %d", len(ig.features), ig.numFeatures))
 	}
 
+	// 恢复每个标签的特征排名，旧版本的模型中没有排名
+	ig.targets = nil
+	ig.classRankings = make(map[string][]utils.FeatureScore, len(model.GetClassRankings()))
+	for _, ranking := range model.GetClassRankings() {
+		if len(ranking.GetFeatures()) != len(ranking.GetScores()) {
+			return fmt.Errorf("标签 %s 的特征数量(%d)与分数数量(%d)不一致", ranking.GetLabel(), len(ranking.GetFeatures()), len(ranking.GetScores()))
+		}
+		features := make([]utils.FeatureScore, len(ranking.GetFeatures()))
+		for i, feature := range ranking.GetFeatures() {
+			features[i] = utils.FeatureScore{Feature: feature, Score: ranking.GetScores()[i]}
+		}
+		ig.targets = append(ig.targets, ranking.GetLabel())
+		ig.classRankings[ranking.GetLabel()] = features
+	}
+
+	return nil
+}
+
+// SaveCheckpoint 将 PartialFit 累计的计数保存到文件，之后可以用 LoadCheckpoint 恢复并继续训练
+// 检查点只包含计数，不包含 maxFeatures 和打分函数，恢复时使用当前模型的设置
+func (ig *InfoGain) SaveCheckpoint(filename string) error {
+	counts := &infogainpb.InfoGainCounts{
+		TotalDocs:  int64(ig.totalDocs),
+		TargetFreq: make(map[string]int64, len(ig.targetFreq)),
+		Features:   make([]*infogainpb.FeatureCounts, 0, len(ig.featureFreq)),
+	}
+	for target, freq := range ig.targetFreq {
+		counts.TargetFreq[target] = int64(freq)
+	}
+
+	features := make([]string, 0, len(ig.featureFreq))
+	for feature := range ig.featureFreq {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	for _, feature := range features {
+		fc := &infogainpb.FeatureCounts{
+			Feature:   feature,
+			DocFreq:   int64(ig.featureFreq[feature]),
+			LabelFreq: make(map[string]int64, len(ig.featureInLabel[feature])),
+		}
+		for target, freq := range ig.featureInLabel[feature] {
+			fc.LabelFreq[target] = int64(freq)
+		}
+		counts.Features = append(counts.Features, fc)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(counts)
+	if err != nil {
+		return fmt.Errorf("无法序列化检查点: %v", err)
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
+
+// LoadCheckpoint 从文件恢复 SaveCheckpoint 保存的计数，替换当前累计的计数
+// 之后可以继续调用 PartialFit，或直接调用 Finalize
+func (ig *InfoGain) LoadCheckpoint(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("无法读取文件: %v", err)
+	}
+
+	counts := &infogainpb.InfoGainCounts{}
+	if err := proto.Unmarshal(data, counts); err != nil {
+		return fmt.Errorf("无法反序列化检查点: %v", err)
+	}
+
+	totalDocs := int64(0)
+	for _, freq := range counts.GetTargetFreq() {
+		totalDocs += freq
+	}
+	if totalDocs != counts.GetTotalDocs() {
+		return fmt.Errorf("检查点的文档总数(%d)与各标签的文档数之和(%d)不一致", counts.GetTotalDocs(), totalDocs)
+	}
+
+	targetFreq := make(map[string]int, len(counts.GetTargetFreq()))
+	for target, freq := range counts.GetTargetFreq() {
+		targetFreq[target] = int(freq)
+	}
+	featureFreq := make(map[string]int, len(counts.GetFeatures()))
+	featureInLabel := make(map[string]map[string]int, len(counts.GetFeatures()))
+	for _, fc := range counts.GetFeatures() {
+		if fc.GetDocFreq() > totalDocs {
+			return fmt.Errorf("特征 %s 的文档数(%d)超过文档总数(%d)", fc.GetFeature(), fc.GetDocFreq(), totalDocs)
+		}
+		featureFreq[fc.GetFeature()] = int(fc.GetDocFreq())
+		inLabel := make(map[string]int, len(fc.GetLabelFreq()))
+		for target, freq := range fc.GetLabelFreq() {
+			inLabel[target] = int(freq)
+		}
+		featureInLabel[fc.GetFeature()] = inLabel
+	}
+
+	ig.totalDocs = int(totalDocs)
+	ig.targetFreq = targetFreq
+	ig.featureFreq = featureFreq
+	ig.featureInLabel = featureInLabel
 	return nil
 }
+
+// sortFeatureScores 按分数从高到低排序，分数相同时按特征名排序
+func sortFeatureScores(scores []utils.FeatureScore) {
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score == scores[j].Score {
+			return scores[i].Feature < scores[j].Feature
+		}
+		return scores[i].Score > scores[j].Score
+	})
+}
@@ -1,11 +1,18 @@
 package infogain
 
 import (
+	"bytes"
+	"fmt"
 	"math"
+	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/yinziyang/mlkit/matrix"
 )
 
 // TestInfoGainFit 测试使用原始文本的Fit方法
@@ -267,4 +274,374 @@ func TestInfoGainSaveLoad(t *testing.T) {
 	if !reflect.DeepEqual(originalIG.vocab, loadedIG.vocab) {
 		t.Errorf("词汇表不匹配: \n期望 %v, \n得到 %v", originalIG.vocab, loadedIG.vocab)
 	}
+
+	// 比较每个标签的特征排名
+	if !reflect.DeepEqual(originalIG.GetTargets(), loadedIG.GetTargets()) {
+		t.Errorf("标签不匹配: 期望 %v, 得到 %v", originalIG.GetTargets(), loadedIG.GetTargets())
+	}
+	for _, label := range originalIG.GetTargets() {
+		if !reflect.DeepEqual(originalIG.TopFeaturesForClass(label, 0), loadedIG.TopFeaturesForClass(label, 0)) {
+			t.Errorf("标签 %s 的特征排名不匹配: \n期望 %v, \n得到 %v", label, originalIG.TopFeaturesForClass(label, 0), loadedIG.TopFeaturesForClass(label, 0))
+		}
+	}
+}
+
+// TestInfoGainTopFeaturesForClass 测试每个标签的特征排名
+// 验证:
+// 1. 只包含与标签正相关的特征，分数为一对其余的信息增益
+// 2. 分数相同时按特征名排序，多次训练的结果相同
+// 3. 设置 maxFeatures 时只保留选中的特征
+func TestInfoGainTopFeaturesForClass(t *testing.T) {
+	tokens := [][]string{
+		{"python", "java", "编程", "代码"},
+		{"代码", "开发", "python", "程序", "测试"},
+		{"编程", "开发", "测试"},
+		{"数据", "分析", "python", "统计"},
+		{"机器学习", "数据", "分析", "模型"},
+		{"网络", "服务器", "安全"},
+		{"服务器", "网络", "运维", "监控"},
+	}
+	targets := []string{"0", "0", "0", "1", "1", "2", "2"}
+
+	type ranked struct {
+		feature string
+		score   float64
+	}
+	expected := map[string][]ranked{
+		"0": {{"代码", 0.469565}, {"开发", 0.469565}, {"测试", 0.469565}, {"编程", 0.469565}, {"java", 0.198117}, {"程序", 0.198117}, {"python", 0.128085}},
+		"1": {{"分析", 0.863121}, {"数据", 0.863121}, {"机器学习", 0.305958}, {"模型", 0.305958}, {"统计", 0.305958}, {"python", 0.005978}},
+		"2": {{"服务器", 0.863121}, {"网络", 0.863121}, {"安全", 0.305958}, {"监控", 0.305958}, {"运维", 0.305958}},
+	}
+
+	ig := NewInfoGain()
+	ig.FitWithTokens(tokens, targets)
+
+	if !reflect.DeepEqual(ig.GetTargets(), []string{"0", "1", "2"}) {
+		t.Errorf("标签不匹配: 得到 %v", ig.GetTargets())
+	}
+	for label, want := range expected {
+		got := ig.TopFeaturesForClass(label, 0)
+		if len(got) != len(want) {
+			t.Fatalf("标签 %s 的特征数量不匹配: 期望 %d, 得到 %v", label, len(want), got)
+		}
+		for i := range want {
+			if got[i].Feature != want[i].feature || math.Abs(got[i].Score-want[i].score) > 1e-6 {
+				t.Errorf("标签 %s 第%d个特征: 期望 %s(%.6f), 得到 %s(%.6f)", label, i, want[i].feature, want[i].score, got[i].Feature, got[i].Score)
+			}
+		}
+	}
+	if got := ig.TopFeaturesForClass("1", 2); len(got) != 2 || got[0].Feature != "分析" || got[1].Feature != "数据" {
+		t.Errorf("TopFeaturesForClass(\"1\", 2) = %v", got)
+	}
+	if got := ig.TopFeaturesForClass("3", 2); got != nil {
+		t.Errorf("不存在的标签应返回nil, 得到 %v", got)
+	}
+
+	// 多次训练的结果相同
+	for i := 0; i < 5; i++ {
+		again := NewInfoGain(2)
+		again.FitWithTokens(tokens, targets)
+		wantFeatures := []string{"代码", "分析", "开发", "数据", "服务器", "网络"}
+		if !reflect.DeepEqual(again.features, wantFeatures) {
+			t.Fatalf("第%d次训练选中的特征不匹配: 期望 %v, 得到 %v", i, wantFeatures, again.features)
+		}
+		for _, label := range again.GetTargets() {
+			for _, fs := range again.TopFeaturesForClass(label, 0) {
+				if !again.vocab[fs.Feature] {
+					t.Errorf("标签 %s 的排名包含未选中的特征 %s", label, fs.Feature)
+				}
+			}
+		}
+	}
+}
+
+// TestInfoGainTransformWithTokens 测试TransformWithTokens的输出
+// 验证:
+// 1. 返回的矩阵为CSR格式，行指针与文档一一对应
+// 2. 每行的列索引有序且与特征列表一致
+// 3. 归一化后每行的L2范数为1
+func TestInfoGainTransformWithTokens(t *testing.T) {
+	tokens := [][]string{
+		{"python", "java", "编程", "代码"},
+		{"代码", "开发", "python", "程序", "测试"},
+		{"编程", "开发", "测试"},
+		{"数据", "分析", "python", "统计"},
+		{"机器学习", "数据", "分析", "模型"},
+		{"网络", "服务器", "安全"},
+		{"服务器", "网络", "运维", "监控"},
+	}
+	targets := []string{"0", "0", "0", "1", "1", "2", "2"}
+
+	ig := NewInfoGain(2)
+	ig.FitWithTokens(tokens, targets)
+	X, features := ig.TransformWithTokens(tokens, true)
+
+	if X.Rows != len(tokens) || X.Cols != len(features) {
+		t.Fatalf("矩阵维度不匹配: 期望 (%d, %d), 得到 (%d, %d)", len(tokens), len(features), X.Rows, X.Cols)
+	}
+	if len(X.RowPtr) != X.Rows+1 {
+		t.Fatalf("行指针长度不匹配: 期望 %d, 得到 %d", X.Rows+1, len(X.RowPtr))
+	}
+	if !reflect.DeepEqual(X.FeatureNames, features) {
+		t.Errorf("矩阵的特征名不匹配: 期望 %v, 得到 %v", features, X.FeatureNames)
+	}
+
+	for i, doc := range tokens {
+		present := make(map[string]bool)
+		for _, token := range doc {
+			present[token] = true
+		}
+
+		cols, data := X.Row(i)
+		var wantCols []int
+		for j, feature := range features {
+			if present[feature] {
+				wantCols = append(wantCols, j)
+			}
+		}
+		if len(cols) != len(wantCols) {
+			t.Errorf("第%d行的非零元素数量不匹配: 期望 %v, 得到 %v", i, wantCols, cols)
+			continue
+		}
+
+		norm := 0.0
+		for k := range cols {
+			if cols[k] != wantCols[k] {
+				t.Errorf("第%d行的列索引不匹配: 期望 %v, 得到 %v", i, wantCols, cols)
+				break
+			}
+			norm += data[k] * data[k]
+		}
+		if len(cols) > 0 && math.Abs(norm-1) > 1e-9 {
+			t.Errorf("第%d行的L2范数不为1: %v", i, math.Sqrt(norm))
+		}
+	}
+}
+
+// TestInfoGainTransformChunked 测试逐行转换和分块转换的结果与 TransformWithTokens 相同
+func TestInfoGainTransformChunked(t *testing.T) {
+	tokens := [][]string{
+		{"python", "java", "编程", "代码", "python"},
+		{"代码", "开发", "python", "程序", "测试"},
+		{"编程", "开发", "测试", "未知"},
+		{"数据", "分析", "python", "统计"},
+		{"机器学习", "数据", "分析", "模型"},
+		{"网络", "服务器", "安全"},
+		{"服务器", "网络", "运维", "监控"},
+	}
+	targets := []string{"0", "0", "0", "1", "1", "2", "2"}
+
+	ig := NewInfoGain()
+	ig.FitWithTokens(tokens, targets)
+
+	for _, normalize := range []bool{false, true} {
+		want, _ := ig.TransformWithTokens(tokens, normalize)
+
+		for i, doc := range tokens {
+			cols, data := ig.TransformRow(doc, normalize)
+			wantCols, wantData := want.Row(i)
+			if !reflect.DeepEqual(cols, wantCols) || !reflect.DeepEqual(data, wantData) {
+				t.Errorf("TransformRow(%v) = %v, %v, 期望 %v, %v", doc, cols, data, wantCols, wantData)
+			}
+		}
+
+		ch := make(chan []string)
+		go func() {
+			for _, doc := range tokens {
+				ch <- doc
+			}
+			close(ch)
+		}()
+		cm, err := ig.TransformChunked(ch, normalize, matrix.ChunkedOptions{ChunkRows: 3, SpillDir: t.TempDir()})
+		if err != nil {
+			t.Fatalf("TransformChunked() 失败: %v", err)
+		}
+		if cm.NumChunks() != 3 {
+			t.Errorf("块数量不匹配: 期望 3, 得到 %d", cm.NumChunks())
+		}
+		got, err := cm.ToSparse()
+		if err != nil {
+			t.Fatalf("ToSparse() 失败: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("TransformChunked() 结果不匹配: 期望 %+v, 得到 %+v", want, got)
+		}
+		cm.Remove()
+	}
+
+	// 出错时删除已溢写的文件，并读完通道中剩余的数据，发送方不会被阻塞
+	ch := make(chan []string)
+	sent := make(chan struct{})
+	go func() {
+		for _, doc := range tokens {
+			ch <- doc
+		}
+		close(ch)
+		close(sent)
+	}()
+	missing := filepath.Join(t.TempDir(), "missing")
+	if _, err := ig.TransformChunked(ch, true, matrix.ChunkedOptions{ChunkRows: 1, SpillDir: missing}); err == nil {
+		t.Error("TransformChunked() 溢写目录不存在时应返回错误")
+	}
+	<-sent
+}
+
+// TestInfoGainDeterministic 测试训练和转换结果的确定性
+// 在不同的 GOMAXPROCS 下多次训练，验证:
+// 1. 保存的模型文件逐字节相同
+// 2. 转换得到的矩阵逐字节相同
+func TestInfoGainDeterministic(t *testing.T) {
+	// 大量特征的分数相同，选择结果依赖于确定的排序规则
+	tokens, targets := randomCorpus(1, 400)
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	dir := t.TempDir()
+
+	var wantModel, wantMatrix []byte
+	for _, procs := range []int{1, 2, 3, 8, 1, 8} {
+		runtime.GOMAXPROCS(procs)
+
+		ig := NewInfoGain(10)
+		X, _ := ig.FitTransformWithTokens(tokens, targets, true)
+
+		filename := filepath.Join(dir, fmt.Sprintf("model-%d.pb", procs))
+		if err := ig.Save(filename); err != nil {
+			t.Fatalf("保存模型失败: %v", err)
+		}
+		model, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("无法读取模型: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := X.WriteBinary(&buf, matrix.BinaryOptions{}); err != nil {
+			t.Fatalf("写入矩阵失败: %v", err)
+		}
+
+		if wantModel == nil {
+			wantModel, wantMatrix = model, buf.Bytes()
+			continue
+		}
+		if !bytes.Equal(model, wantModel) {
+			t.Errorf("GOMAXPROCS=%d: 保存的模型与第一次训练的结果不同", procs)
+		}
+		if !bytes.Equal(buf.Bytes(), wantMatrix) {
+			t.Errorf("GOMAXPROCS=%d: 转换得到的矩阵与第一次训练的结果不同", procs)
+		}
+	}
+}
+
+// randomCorpus 随机生成 n 篇已分词的文本及其标签
+// 每篇文本一半的词来自标签专属的词表，一半来自公共词表
+func randomCorpus(seed int64, n int) ([][]string, []string) {
+	rng := rand.New(rand.NewSource(seed))
+	labels := []string{"a", "b", "c", "d"}
+	var tokens [][]string
+	var targets []string
+	for i := 0; i < n; i++ {
+		label := labels[rng.Intn(len(labels))]
+		doc := make([]string, 0, 12)
+		for j := 0; j < 12; j++ {
+			if rng.Intn(2) == 0 {
+				doc = append(doc, fmt.Sprintf("%s%d", label, rng.Intn(30)))
+			} else {
+				doc = append(doc, fmt.Sprintf("w%d", rng.Intn(200)))
+			}
+		}
+		tokens = append(tokens, doc)
+		targets = append(targets, label)
+	}
+	return tokens, targets
+}
+
+// TestInfoGainPartialFit 测试分批训练
+// 验证:
+// 1. 分批调用 PartialFit 后 Finalize 的结果与 FitWithTokens 逐字节相同
+// 2. 从检查点恢复后继续训练，结果不变
+// 3. 错误的输入返回错误
+func TestInfoGainPartialFit(t *testing.T) {
+	tokens, targets := randomCorpus(2, 500)
+	dir := t.TempDir()
+
+	saveModel := func(ig *InfoGain, name string) []byte {
+		filename := filepath.Join(dir, name)
+		if err := ig.Save(filename); err != nil {
+			t.Fatalf("保存模型失败: %v", err)
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("无法读取模型: %v", err)
+		}
+		return data
+	}
+
+	full := NewInfoGain(10)
+	full.FitWithTokens(tokens, targets)
+	want := saveModel(full, "full.pb")
+
+	// 分批统计，中途保存检查点，由新的模型恢复后继续统计
+	batches := []int{0, 1, 120, 200, 379, 500}
+	checkpoint := filepath.Join(dir, "checkpoint.pb")
+	ig := NewInfoGain(10)
+	for k := 0; k+1 < len(batches); k++ {
+		start, end := batches[k], batches[k+1]
+		if err := ig.PartialFit(tokens[start:end], targets[start:end]); err != nil {
+			t.Fatalf("PartialFit() error = %v", err)
+		}
+		if k == 2 {
+			if err := ig.SaveCheckpoint(checkpoint); err != nil {
+				t.Fatalf("SaveCheckpoint() error = %v", err)
+			}
+			ig = NewInfoGain(10)
+			if err := ig.LoadCheckpoint(checkpoint); err != nil {
+				t.Fatalf("LoadCheckpoint() error = %v", err)
+			}
+			if ig.NumDocs() != end {
+				t.Fatalf("NumDocs() = %d, 期望 %d", ig.NumDocs(), end)
+			}
+		}
+	}
+	if err := ig.Finalize(); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+	if got := saveModel(ig, "partial.pb"); !bytes.Equal(got, want) {
+		t.Error("分批训练的模型与 FitWithTokens 的结果不同")
+	}
+	if !reflect.DeepEqual(ig.features, full.features) {
+		t.Errorf("特征列表不匹配: \n期望 %v, \n得到 %v", full.features, ig.features)
+	}
+
+	// Finalize 之后可以继续统计
+	more, moreTargets := randomCorpus(3, 50)
+	if err := ig.PartialFit(more, moreTargets); err != nil {
+		t.Fatalf("PartialFit() error = %v", err)
+	}
+	if err := ig.Finalize(); err != nil {
+		t.Fatalf("Finalize() error = %v", err)
+	}
+	full.FitWithTokens(append(append([][]string{}, tokens...), more...), append(append([]string{}, targets...), moreTargets...))
+	if !bytes.Equal(saveModel(ig, "partial2.pb"), saveModel(full, "full2.pb")) {
+		t.Error("Finalize 之后继续分批训练的模型与 FitWithTokens 的结果不同")
+	}
+
+	// 错误的输入
+	if err := NewInfoGain().Finalize(); err == nil {
+		t.Error("Finalize() 没有统计任何文档时应返回错误")
+	}
+	if err := NewInfoGain().PartialFit(tokens[:2], targets[:1]); err == nil {
+		t.Error("PartialFit() 文本数量与标签数量不一致时应返回错误")
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "不一致") {
+				t.Errorf("FitWithTokens() 文本数量与标签数量不一致时应 panic 并说明原因, 得到 %v", r)
+			}
+		}()
+		NewInfoGain().FitWithTokens(tokens[:3], targets[:1])
+	}()
+	if err := NewInfoGain().LoadCheckpoint(filepath.Join(dir, "missing.pb")); err == nil {
+		t.Error("LoadCheckpoint() 文件不存在时应返回错误")
+	}
+	if err := NewInfoGain().LoadCheckpoint(filepath.Join(dir, "full.pb")); err == nil {
+		t.Error("LoadCheckpoint() 读取模型文件时应返回错误")
+	}
 }
@@ -9,4 +9,27 @@ message InfoGainModel {
     map<string, int32> feature_to_index = 2;  // 特征到索引的映射
     map<string, double> scores = 3;   // scores 的 key 就是特征词
     int32 num_features = 4;
-}
\ No newline at end of file
+    string scorer = 5;  // 打分函数的名称，为空时表示信息增益(互信息)
+    repeated ClassRanking class_rankings = 6;  // 每个标签的特征排名，按标签的字典序排列
+}
+
+// ClassRanking 存储一个标签下与其正相关的特征，按标签条件分数从高到低排列
+message ClassRanking {
+    string label = 1;
+    repeated string features = 2;
+    repeated double scores = 3;  // 与 features 一一对应的分数
+}
+
+// InfoGainCounts 存储 PartialFit 累计的计数，用于保存和恢复训练的检查点
+message InfoGainCounts {
+    int64 total_docs = 1;  // 已统计的文档总数
+    map<string, int64> target_freq = 2;  // 每个标签的文档数
+    repeated FeatureCounts features = 3;  // 按特征名的字典序排列
+}
+
+// FeatureCounts 存储一个特征的文档数
+message FeatureCounts {
+    string feature = 1;
+    int64 doc_freq = 2;  // 包含该特征的文档数
+    map<string, int64> label_freq = 3;  // 每个标签中包含该特征的文档数
+}
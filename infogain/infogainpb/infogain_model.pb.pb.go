@@ -30,6 +30,8 @@ type InfoGainModel struct {
 	FeatureToIndex map[string]int32   `protobuf:"bytes,2,rep,name=feature_to_index,json=featureToIndex,proto3" json:"feature_to_index,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // 特征到索引的映射
 	Scores         map[string]float64 `protobuf:"bytes,3,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`                                        // scores 的 key 就是特征词
 	NumFeatures    int32              `protobuf:"varint,4,opt,name=num_features,json=numFeatures,proto3" json:"num_features,omitempty"`
+	Scorer         string             `protobuf:"bytes,5,opt,name=scorer,proto3" json:"scorer,omitempty"`                                    // 打分函数的名称，为空时表示信息增益(互信息)
+	ClassRankings  []*ClassRanking    `protobuf:"bytes,6,rep,name=class_rankings,json=classRankings,proto3" json:"class_rankings,omitempty"` // 每个标签的特征排名，按标签的字典序排列
 }
 
 func (x *InfoGainModel) Reset() {
@@ -92,12 +94,218 @@ func (x *InfoGainModel) GetNumFeatures() int32 {
 	return 0
 }
 
+func (x *InfoGainModel) GetScorer() string {
+	if x != nil {
+		return x.Scorer
+	}
+	return ""
+}
+
+func (x *InfoGainModel) GetClassRankings() []*ClassRanking {
+	if x != nil {
+		return x.ClassRankings
+	}
+	return nil
+}
+
+// ClassRanking 存储一个标签下与其正相关的特征，按标签条件分数从高到低排列
+type ClassRanking struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Label    string    `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Features []string  `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	Scores   []float64 `protobuf:"fixed64,3,rep,packed,name=scores,proto3" json:"scores,omitempty"` // 与 features 一一对应的分数
+}
+
+func (x *ClassRanking) Reset() {
+	*x = ClassRanking{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_infogain_model_pb_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClassRanking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassRanking) ProtoMessage() {}
+
+func (x *ClassRanking) ProtoReflect() protoreflect.Message {
+	mi := &file_infogain_model_pb_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassRanking.ProtoReflect.Descriptor instead.
+func (*ClassRanking) Descriptor() ([]byte, []int) {
+	return file_infogain_model_pb_rawDescGZIP(), []int{1}
+}
+
+func (x *ClassRanking) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ClassRanking) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *ClassRanking) GetScores() []float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+// InfoGainCounts 存储 PartialFit 累计的计数，用于保存和恢复训练的检查点
+type InfoGainCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalDocs  int64            `protobuf:"varint,1,opt,name=total_docs,json=totalDocs,proto3" json:"total_docs,omitempty"`                                                                                            // 已统计的文档总数
+	TargetFreq map[string]int64 `protobuf:"bytes,2,rep,name=target_freq,json=targetFreq,proto3" json:"target_freq,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // 每个标签的文档数
+	Features   []*FeatureCounts `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`                                                                                                                // 按特征名的字典序排列
+}
+
+func (x *InfoGainCounts) Reset() {
+	*x = InfoGainCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_infogain_model_pb_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InfoGainCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoGainCounts) ProtoMessage() {}
+
+func (x *InfoGainCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_infogain_model_pb_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoGainCounts.ProtoReflect.Descriptor instead.
+func (*InfoGainCounts) Descriptor() ([]byte, []int) {
+	return file_infogain_model_pb_rawDescGZIP(), []int{2}
+}
+
+func (x *InfoGainCounts) GetTotalDocs() int64 {
+	if x != nil {
+		return x.TotalDocs
+	}
+	return 0
+}
+
+func (x *InfoGainCounts) GetTargetFreq() map[string]int64 {
+	if x != nil {
+		return x.TargetFreq
+	}
+	return nil
+}
+
+func (x *InfoGainCounts) GetFeatures() []*FeatureCounts {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// FeatureCounts 存储一个特征的文档数
+type FeatureCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Feature   string           `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	DocFreq   int64            `protobuf:"varint,2,opt,name=doc_freq,json=docFreq,proto3" json:"doc_freq,omitempty"`                                                                                               // 包含该特征的文档数
+	LabelFreq map[string]int64 `protobuf:"bytes,3,rep,name=label_freq,json=labelFreq,proto3" json:"label_freq,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // 每个标签中包含该特征的文档数
+}
+
+func (x *FeatureCounts) Reset() {
+	*x = FeatureCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_infogain_model_pb_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeatureCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureCounts) ProtoMessage() {}
+
+func (x *FeatureCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_infogain_model_pb_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureCounts.ProtoReflect.Descriptor instead.
+func (*FeatureCounts) Descriptor() ([]byte, []int) {
+	return file_infogain_model_pb_rawDescGZIP(), []int{3}
+}
+
+func (x *FeatureCounts) GetFeature() string {
+	if x != nil {
+		return x.Feature
+	}
+	return ""
+}
+
+func (x *FeatureCounts) GetDocFreq() int64 {
+	if x != nil {
+		return x.DocFreq
+	}
+	return 0
+}
+
+func (x *FeatureCounts) GetLabelFreq() map[string]int64 {
+	if x != nil {
+		return x.LabelFreq
+	}
+	return nil
+}
+
 var File_infogain_model_pb protoreflect.FileDescriptor
 
 var file_infogain_model_pb_rawDesc = []byte{
 	0x0a, 0x11, 0x69, 0x6e, 0x66, 0x6f, 0x67, 0x61, 0x69, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
 	0x2e, 0x70, 0x62, 0x12, 0x0e, 0x69, 0x6e, 0x66, 0x6f, 0x67, 0x61, 0x69, 0x6e, 0x5f, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x22, 0xf3, 0x02, 0x0a, 0x0d, 0x49, 0x6e, 0x66, 0x6f, 0x47, 0x61, 0x69, 0x6e,
+	0x64, 0x65, 0x6c, 0x22, 0xd0, 0x03, 0x0a, 0x0d, 0x49, 0x6e, 0x66, 0x6f, 0x47, 0x61, 0x69, 0x6e,
 	0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x65, 0x61,
 	0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78,
 	0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x5b, 0x0a, 0x10, 0x66, 0x65, 0x61, 0x74,
@@ -112,17 +320,57 @@ var file_infogain_model_pb_rawDesc = []byte{
 	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
 	0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x75, 0x6d, 0x5f,
 	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
-	0x6e, 0x75, 0x6d, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x1a, 0x41, 0x0a, 0x13, 0x46,
-	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x54, 0x6f, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39,
-	0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0f, 0x5a, 0x0d, 0x2e, 0x2f, 0x3b,
-	0x69, 0x6e, 0x66, 0x6f, 0x67, 0x61, 0x69, 0x6e, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x6e, 0x75, 0x6d, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x72, 0x12, 0x43, 0x0a, 0x0e, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x72, 0x61, 0x6e,
+	0x6b, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6e,
+	0x66, 0x6f, 0x67, 0x61, 0x69, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x73, 0x1a, 0x41, 0x0a, 0x13, 0x46, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x54, 0x6f, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x58, 0x0a, 0x0c, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52,
+	0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73,
+	0x22, 0xfa, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x66, 0x6f, 0x47, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x6f, 0x63,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x6f,
+	0x63, 0x73, 0x12, 0x4f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x66, 0x72, 0x65,
+	0x71, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x67, 0x61,
+	0x69, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x47, 0x61, 0x69,
+	0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x72,
+	0x65, 0x71, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46,
+	0x72, 0x65, 0x71, 0x12, 0x39, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x67, 0x61, 0x69, 0x6e,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x72, 0x65, 0x71, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcf, 0x01,
+	0x0a, 0x0d, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x6f, 0x63,
+	0x5f, 0x66, 0x72, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x6f, 0x63,
+	0x46, 0x72, 0x65, 0x71, 0x12, 0x4b, 0x0a, 0x0a, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x66, 0x72,
+	0x65, 0x71, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x67,
+	0x61, 0x69, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x72, 0x65,
+	0x71, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x72, 0x65,
+	0x71, 0x1a, 0x3c, 0x0a, 0x0e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x72, 0x65, 0x71, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42,
+	0x0f, 0x5a, 0x0d, 0x2e, 0x2f, 0x3b, 0x69, 0x6e, 0x66, 0x6f, 0x67, 0x61, 0x69, 0x6e, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -137,20 +385,29 @@ func file_infogain_model_pb_rawDescGZIP() []byte {
 	return file_infogain_model_pb_rawDescData
 }
 
-var file_infogain_model_pb_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_infogain_model_pb_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_infogain_model_pb_goTypes = []interface{}{
-	(*InfoGainModel)(nil), // 0: infogain_model.InfoGainModel
-	nil,                   // 1: infogain_model.InfoGainModel.FeatureToIndexEntry
-	nil,                   // 2: infogain_model.InfoGainModel.ScoresEntry
+	(*InfoGainModel)(nil),  // 0: infogain_model.InfoGainModel
+	(*ClassRanking)(nil),   // 1: infogain_model.ClassRanking
+	(*InfoGainCounts)(nil), // 2: infogain_model.InfoGainCounts
+	(*FeatureCounts)(nil),  // 3: infogain_model.FeatureCounts
+	nil,                    // 4: infogain_model.InfoGainModel.FeatureToIndexEntry
+	nil,                    // 5: infogain_model.InfoGainModel.ScoresEntry
+	nil,                    // 6: infogain_model.InfoGainCounts.TargetFreqEntry
+	nil,                    // 7: infogain_model.FeatureCounts.LabelFreqEntry
 }
 var file_infogain_model_pb_depIdxs = []int32{
-	1, // 0: infogain_model.InfoGainModel.feature_to_index:type_name -> infogain_model.InfoGainModel.FeatureToIndexEntry
-	2, // 1: infogain_model.InfoGainModel.scores:type_name -> infogain_model.InfoGainModel.ScoresEntry
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 0: infogain_model.InfoGainModel.feature_to_index:type_name -> infogain_model.InfoGainModel.FeatureToIndexEntry
+	5, // 1: infogain_model.InfoGainModel.scores:type_name -> infogain_model.InfoGainModel.ScoresEntry
+	1, // 2: infogain_model.InfoGainModel.class_rankings:type_name -> infogain_model.ClassRanking
+	6, // 3: infogain_model.InfoGainCounts.target_freq:type_name -> infogain_model.InfoGainCounts.TargetFreqEntry
+	3, // 4: infogain_model.InfoGainCounts.features:type_name -> infogain_model.FeatureCounts
+	7, // 5: infogain_model.FeatureCounts.label_freq:type_name -> infogain_model.FeatureCounts.LabelFreqEntry
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_infogain_model_pb_init() }
@@ -171,6 +428,42 @@ func file_infogain_model_pb_init() {
 				return nil
 			}
 		}
+		file_infogain_model_pb_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClassRanking); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_infogain_model_pb_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InfoGainCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_infogain_model_pb_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeatureCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -178,7 +471,7 @@ func file_infogain_model_pb_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_infogain_model_pb_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,266 @@
+package infogain
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// FeatureStats 是计算一个特征的分数所需的文档计数
+type FeatureStats struct {
+	FeatureCount   int            // 包含该特征的文档数
+	FeatureInLabel map[string]int // 每个标签中包含该特征的文档数
+	TargetFreq     map[string]int // 每个标签的文档数
+	Targets        []string       // 按字典序排列的标签，打分函数按此顺序求和，保证结果确定
+	TotalDocs      int            // 文档总数
+}
+
+// labelCounts 返回特征与标签 target 的2×2列联表
+// a: 包含特征且属于该标签，b: 包含特征但不属于该标签，
+// c: 不包含特征但属于该标签，d: 既不包含特征也不属于该标签
+func (s *FeatureStats) labelCounts(target string) (a, b, c, d float64) {
+	a = float64(s.FeatureInLabel[target])
+	b = float64(s.FeatureCount) - a
+	c = float64(s.TargetFreq[target]) - a
+	d = float64(s.TotalDocs) - a - b - c
+	return a, b, c, d
+}
+
+// positivelyAssociated 判断特征是否与标签 target 正相关，即 P(target|f) > P(target)
+func (s *FeatureStats) positivelyAssociated(target string) bool {
+	return s.FeatureInLabel[target]*s.TotalDocs > s.TargetFreq[target]*s.FeatureCount
+}
+
+// Scorer 根据文档计数计算特征的分数，分数越高表示特征越重要
+// 所有打分函数共享 InfoGain 的计数、按标签选择特征和模型格式
+type Scorer interface {
+	// Name 返回打分函数的名称，保存在模型文件中，加载时通过 ScorerByName 还原
+	Name() string
+	// Score 返回特征的分数
+	Score(stats *FeatureStats) float64
+}
+
+// ClassScorer 是可选的接口，计算特征对单个标签（一对其余）的分数，用于按标签选择和排列特征
+// 没有实现该接口的打分函数按 Score 的结果选择特征，并使用 Score 的结果作为每个标签的分数
+type ClassScorer interface {
+	// ClassScore 返回特征对标签 target 的分数
+	ClassScore(stats *FeatureStats, target string) float64
+}
+
+// classScore 返回特征对标签 target 的分数
+func classScore(scorer Scorer, stats *FeatureStats, target string) float64 {
+	if cs, ok := scorer.(ClassScorer); ok {
+		return cs.ClassScore(stats, target)
+	}
+	return scorer.Score(stats)
+}
+
+// MutualInformation 以信息增益（即特征与标签的互信息）作为分数，单位为bit，这是 InfoGain 的默认打分函数
+//
+//	IG(f) = H(Y) - H(Y|f)
+type MutualInformation struct{}
+
+// Name 实现 Scorer 接口
+func (MutualInformation) Name() string { return "mutual_information" }
+
+// Score 实现 Scorer 接口
+func (MutualInformation) Score(stats *FeatureStats) float64 {
+	totalDocs := float64(stats.TotalDocs)
+	labelEntropy := 0.0
+	for _, target := range stats.Targets {
+		p := float64(stats.TargetFreq[target]) / totalDocs
+		if p > 0 {
+			labelEntropy -= p * math.Log2(p)
+		}
+	}
+	return labelEntropy - calculateFeatureEntropy(stats.FeatureInLabel, stats.TargetFreq, stats.Targets, totalDocs, float64(stats.FeatureCount))
+}
+
+// ClassScore 实现 ClassScorer 接口，返回特征对"是否属于该标签"这一二值变量的信息增益
+func (MutualInformation) ClassScore(stats *FeatureStats, target string) float64 {
+	a, b, c, d := stats.labelCounts(target)
+	n := a + b + c + d
+	present, absent := a+b, c+d
+
+	conditional := 0.0
+	if present > 0 {
+		conditional += present / n * entropy2(a/present, b/present)
+	}
+	if absent > 0 {
+		conditional += absent / n * entropy2(c/absent, d/absent)
+	}
+	return entropy2((a+c)/n, (b+d)/n) - conditional
+}
+
+// ChiSquare 以卡方统计量作为分数，与 sklearn.feature_selection.chi2 在二值词项出现矩阵上的结果相同
+//
+//	χ²(f) = Σ_y (O(y,f) - E(y,f))² / E(y,f)，E(y,f) = P(y) × df(f)
+type ChiSquare struct{}
+
+// Name 实现 Scorer 接口
+func (ChiSquare) Name() string { return "chi2" }
+
+// Score 实现 Scorer 接口
+func (ChiSquare) Score(stats *FeatureStats) float64 {
+	score := 0.0
+	for _, target := range stats.Targets {
+		observed := float64(stats.FeatureInLabel[target])
+		expected := float64(stats.TargetFreq[target]) / float64(stats.TotalDocs) * float64(stats.FeatureCount)
+		score += (observed - expected) * (observed - expected) / expected
+	}
+	return score
+}
+
+// ClassScore 实现 ClassScorer 接口，返回特征与"是否属于该标签"的2×2列联表的卡方统计量
+func (ChiSquare) ClassScore(stats *FeatureStats, target string) float64 {
+	a, b, c, d := stats.labelCounts(target)
+	denominator := (a + b) * (c + d) * (a + c) * (b + d)
+	if denominator == 0 {
+		return 0
+	}
+	return (a + b + c + d) * (a*d - b*c) * (a*d - b*c) / denominator
+}
+
+// OddsRatio 以对数优势比作为分数，每个计数加0.5平滑，多分类时取各标签（一对其余）中的最大值
+//
+//	OR(f, y) = ln((a+0.5)(d+0.5) / ((b+0.5)(c+0.5)))
+type OddsRatio struct{}
+
+// Name 实现 Scorer 接口
+func (OddsRatio) Name() string { return "odds_ratio" }
+
+// Score 实现 Scorer 接口
+func (o OddsRatio) Score(stats *FeatureStats) float64 {
+	return maxOverLabels(stats, o.ClassScore)
+}
+
+// ClassScore 实现 ClassScorer 接口
+func (OddsRatio) ClassScore(stats *FeatureStats, target string) float64 {
+	a, b, c, d := stats.labelCounts(target)
+	return math.Log((a + 0.5) * (d + 0.5) / ((b + 0.5) * (c + 0.5)))
+}
+
+// Gini 以改进的基尼指数作为分数
+//
+//	Gini(f) = Σ_y P(f|y)² × P(y|f)²
+type Gini struct{}
+
+// Name 实现 Scorer 接口
+func (Gini) Name() string { return "gini" }
+
+// Score 实现 Scorer 接口
+func (g Gini) Score(stats *FeatureStats) float64 {
+	score := 0.0
+	for _, target := range stats.Targets {
+		score += g.ClassScore(stats, target)
+	}
+	return score
+}
+
+// ClassScore 实现 ClassScorer 接口，返回 P(f|y)² × P(y|f)²
+func (Gini) ClassScore(stats *FeatureStats, target string) float64 {
+	inLabel := float64(stats.FeatureInLabel[target])
+	pFeatureGivenLabel := inLabel / float64(stats.TargetFreq[target])
+	pLabelGivenFeature := inLabel / float64(stats.FeatureCount)
+	return pFeatureGivenLabel * pFeatureGivenLabel * pLabelGivenFeature * pLabelGivenFeature
+}
+
+// BiNormalSeparation 以双正态分离度(BNS)作为分数，多分类时取各标签（一对其余）中的最大值
+// 真阳率和假阳率被截断到[0.0005, 0.9995]，避免标准正态分布的分位数为无穷大
+//
+//	BNS(f, y) = |Φ⁻¹(tpr) - Φ⁻¹(fpr)|
+type BiNormalSeparation struct{}
+
+// Name 实现 Scorer 接口
+func (BiNormalSeparation) Name() string { return "bns" }
+
+// Score 实现 Scorer 接口
+func (bns BiNormalSeparation) Score(stats *FeatureStats) float64 {
+	return maxOverLabels(stats, bns.ClassScore)
+}
+
+// ClassScore 实现 ClassScorer 接口
+func (BiNormalSeparation) ClassScore(stats *FeatureStats, target string) float64 {
+	const eps = 0.0005
+	rate := func(x, total float64) float64 {
+		r := 0.0
+		if total > 0 {
+			r = x / total
+		}
+		return math.Min(math.Max(r, eps), 1-eps)
+	}
+
+	a, b, c, d := stats.labelCounts(target)
+	tpr := rate(a, a+c)
+	fpr := rate(b, b+d)
+	return math.Abs(normalQuantile(tpr) - normalQuantile(fpr))
+}
+
+// normalQuantile 返回标准正态分布的分位数 Φ⁻¹(p) = √2 × erfinv(2p - 1)
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// DocumentFrequency 以包含特征的文档数作为分数，不使用标签信息，因此没有实现 ClassScorer
+type DocumentFrequency struct{}
+
+// Name 实现 Scorer 接口
+func (DocumentFrequency) Name() string { return "document_frequency" }
+
+// Score 实现 Scorer 接口
+func (DocumentFrequency) Score(stats *FeatureStats) float64 {
+	return float64(stats.FeatureCount)
+}
+
+// maxOverLabels 对每个标签计算一对其余的二分类分数，返回其中的最大值
+func maxOverLabels(stats *FeatureStats, score func(stats *FeatureStats, target string) float64) float64 {
+	best := math.Inf(-1)
+	for _, target := range stats.Targets {
+		best = math.Max(best, score(stats, target))
+	}
+	return best
+}
+
+// entropy2 返回二值分布(p, q)的熵，单位为bit
+func entropy2(p, q float64) float64 {
+	h := 0.0
+	for _, x := range []float64{p, q} {
+		if x > 0 {
+			h -= x * math.Log2(x)
+		}
+	}
+	return h
+}
+
+var (
+	scorersMu sync.RWMutex
+	scorers   = map[string]Scorer{}
+)
+
+func init() {
+	for _, s := range []Scorer{MutualInformation{}, ChiSquare{}, OddsRatio{}, Gini{}, BiNormalSeparation{}, DocumentFrequency{}} {
+		RegisterScorer(s)
+	}
+}
+
+// RegisterScorer 注册打分函数，使使用它训练的模型可以通过 Load 加载
+// 同名的打分函数会被覆盖
+func RegisterScorer(s Scorer) {
+	scorersMu.Lock()
+	defer scorersMu.Unlock()
+	scorers[s.Name()] = s
+}
+
+// ScorerByName 返回已注册的打分函数，名称为空时返回 MutualInformation
+func ScorerByName(name string) (Scorer, error) {
+	if name == "" {
+		return MutualInformation{}, nil
+	}
+	scorersMu.RLock()
+	defer scorersMu.RUnlock()
+	s, ok := scorers[name]
+	if !ok {
+		return nil, fmt.Errorf("未注册的打分函数: %s", name)
+	}
+	return s, nil
+}
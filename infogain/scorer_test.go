@@ -0,0 +1,162 @@
+package infogain
+
+import (
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestScorers(t *testing.T) {
+	// 特征 "代码" 在7篇文档中出现2次，都属于标签 "0"
+	stats := &FeatureStats{
+		FeatureCount:   2,
+		FeatureInLabel: map[string]int{"0": 2},
+		TargetFreq:     map[string]int{"0": 3, "1": 2, "2": 2},
+		Targets:        []string{"0", "1", "2"},
+		TotalDocs:      7,
+	}
+
+	tests := []struct {
+		scorer Scorer
+		want   float64
+	}{
+		{MutualInformation{}, 0.4696},
+		{ChiSquare{}, 2.666666666666666}, // 与 sklearn.feature_selection.chi2 相同，见 chi2/chi2.py
+		{OddsRatio{}, math.Log(15)},      // 标签 "0": ln(2.5×4.5 / (0.5×1.5))
+		{Gini{}, 4.0 / 9},                // 标签 "0": (2/3)² × (2/2)²
+		{BiNormalSeparation{}, 3.721254030787352},
+		{DocumentFrequency{}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scorer.Name(), func(t *testing.T) {
+			if got := tt.scorer.Score(stats); math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("Score() = %v, 期望 %v", got, tt.want)
+			}
+
+			s, err := ScorerByName(tt.scorer.Name())
+			if err != nil || s != tt.scorer {
+				t.Errorf("ScorerByName(%q) = %v, %v", tt.scorer.Name(), s, err)
+			}
+		})
+	}
+
+	if s, err := ScorerByName(""); err != nil || s != (MutualInformation{}) {
+		t.Errorf("ScorerByName(\"\") = %v, %v, 期望 MutualInformation", s, err)
+	}
+	if _, err := ScorerByName("unknown"); err == nil {
+		t.Error("ScorerByName() 未注册的名称应返回错误")
+	}
+}
+
+func TestInfoGainWithScorer(t *testing.T) {
+	tokens := [][]string{
+		{"python", "java", "编程", "代码"},
+		{"代码", "开发", "python", "程序", "测试"},
+		{"编程", "开发", "测试"},
+		{"数据", "分析", "python", "统计"},
+		{"机器学习", "数据", "分析", "模型"},
+		{"网络", "服务器", "安全"},
+		{"服务器", "网络", "运维", "监控"},
+	}
+	targets := []string{"0", "0", "0", "1", "1", "2", "2"}
+
+	// chi2/chi2.py 中 sklearn.feature_selection.chi2 的结果
+	wantChi2 := map[string]float64{
+		"分析": 5.0, "数据": 5.0, "服务器": 5.0, "网络": 5.0,
+		"代码": 2.666666666666666, "开发": 2.666666666666666, "测试": 2.666666666666666, "编程": 2.666666666666666,
+		"安全": 2.5, "机器学习": 2.5, "模型": 2.5, "监控": 2.5, "统计": 2.5, "运维": 2.5,
+		"java": 1.333333333333333, "程序": 1.333333333333333,
+		"python": 1.2777777777777777,
+	}
+	ig := NewInfoGainWithScorer(ChiSquare{})
+	ig.FitWithTokens(tokens, targets)
+	if ig.Scorer() != (ChiSquare{}) {
+		t.Errorf("Scorer() = %v, 期望 ChiSquare", ig.Scorer())
+	}
+	for _, fs := range ig.GetFeatureScores() {
+		if math.Abs(fs.Score-wantChi2[fs.Feature]) > 1e-12 {
+			t.Errorf("特征 %q 的卡方分数不匹配: 期望 %v, 得到 %v", fs.Feature, wantChi2[fs.Feature], fs.Score)
+		}
+	}
+
+	// 保存和加载后打分函数保持不变
+	tmpfile, err := os.CreateTemp("", "infogain_scorer_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	tmpfile.Close()
+
+	ig = NewInfoGainWithScorer(DocumentFrequency{}, 2)
+	ig.FitWithTokens(tokens, targets)
+	if err := ig.Save(tmpfile.Name()); err != nil {
+		t.Fatalf("保存模型失败: %v", err)
+	}
+	loaded := NewInfoGain()
+	if err := loaded.Load(tmpfile.Name()); err != nil {
+		t.Fatalf("加载模型失败: %v", err)
+	}
+	if loaded.Scorer() != (DocumentFrequency{}) {
+		t.Errorf("加载后的打分函数不匹配: 期望 DocumentFrequency, 得到 %v", loaded.Scorer())
+	}
+	if !reflect.DeepEqual(loaded.GetFeatureScores(), ig.GetFeatureScores()) {
+		t.Errorf("加载后的特征分数不匹配: 期望 %v, 得到 %v", ig.GetFeatureScores(), loaded.GetFeatureScores())
+	}
+}
+
+func TestInfoGainGlobalSelection(t *testing.T) {
+	// "通用" 出现在所有文档中，与任何标签都不正相关
+	tokens := [][]string{
+		{"通用", "a"},
+		{"通用", "a"},
+		{"通用", "b"},
+		{"通用", "b"},
+	}
+
+	tests := []struct {
+		name    string
+		scorer  Scorer
+		targets []string
+		max     int
+		want    map[string]bool
+	}{
+		// DocumentFrequency 不使用标签信息，按全局的文档频率选择
+		{"document_frequency", DocumentFrequency{}, []string{"x", "x", "y", "y"}, 1, map[string]bool{"通用": true}},
+		{"document_frequency_top3", DocumentFrequency{}, []string{"x", "x", "y", "y"}, 3, map[string]bool{"a": true, "b": true, "通用": true}},
+		// 只有一个标签时没有正相关的特征，按全局分数选择
+		{"single_label", MutualInformation{}, []string{"x", "x", "x", "x"}, 2, map[string]bool{"a": true, "b": true}},
+		{"single_label_document_frequency", DocumentFrequency{}, []string{"x", "x", "x", "x"}, 1, map[string]bool{"通用": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ig := NewInfoGainWithScorer(tt.scorer, tt.max)
+			ig.FitWithTokens(tokens, tt.targets)
+			if got := ig.GetVocab(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("选中的特征不匹配: 期望 %v, 得到 %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// constantScorer 是用于测试自定义打分函数的 Scorer
+type constantScorer struct{}
+
+func (constantScorer) Name() string                      { return "constant" }
+func (constantScorer) Score(stats *FeatureStats) float64 { return 1 }
+
+func TestRegisterScorer(t *testing.T) {
+	RegisterScorer(constantScorer{})
+	s, err := ScorerByName("constant")
+	if err != nil || s != (constantScorer{}) {
+		t.Fatalf("ScorerByName(constant) = %v, %v", s, err)
+	}
+
+	ig := NewInfoGainWithScorer(s)
+	ig.FitWithTokens([][]string{{"a", "b"}, {"b"}}, []string{"x", "y"})
+	for _, fs := range ig.GetFeatureScores() {
+		if fs.Score != 1 {
+			t.Errorf("特征 %q 的分数应为1, 得到 %v", fs.Feature, fs.Score)
+		}
+	}
+}
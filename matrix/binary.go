@@ -0,0 +1,291 @@
+package matrix
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+)
+
+// 二进制格式的布局（整数均为无符号varint，除非另有说明）：
+//
+//	magic    4字节 "SPMX"
+//	version  1字节，带有特征名时为2，否则为1，使不带特征名的数据仍可被只支持版本1的旧程序读取
+//	flags    1字节，见 binaryFlag*
+//	rows, cols, nnz
+//	特征名(仅当设置了 binaryFlagFeatureNames 时): cols 个字符串，每个为 长度 + UTF-8字节
+//	每行依次为: 非零元素个数, 列索引增量(有符号varint，行内第一个为列索引本身), 元素值(小端float64或float32)
+//	crc32    4字节小端，覆盖magic到最后一个元素值的全部内容(IEEE多项式)
+const (
+	binaryMagic   = "SPMX"
+	binaryVersion = 2 // 当前支持的最高版本，版本2增加了 binaryFlagFeatureNames
+
+	binaryFlagFloat32      = 1 << 0 // 元素值以float32存储
+	binaryFlagFeatureNames = 1 << 1 // 带有特征名
+
+	binaryKnownFlags = binaryFlagFloat32 | binaryFlagFeatureNames
+
+	// 单个特征名的最大字节数，避免损坏的数据导致过量分配内存
+	binaryMaxNameLen = 1 << 20
+)
+
+// BinaryOptions 写入二进制格式时的选项
+type BinaryOptions struct {
+	Float32 bool // 元素值以float32存储，文件更小但会损失精度；SparseMatrix32 总是以float32存储
+}
+
+// WriteBinary 将稀疏矩阵以紧凑的二进制格式写入 w
+// 列索引按行内增量以varint编码，末尾附带CRC32校验和，可用 ReadBinary 读回
+func (sm *SparseMatrixOf[T]) WriteBinary(w io.Writer, opts BinaryOptions) error {
+	float32Values := opts.Float32 || floatBits[T]() == 32
+	var flags byte
+	if float32Values {
+		flags |= binaryFlagFloat32
+	}
+	if sm.FeatureNames != nil {
+		if len(sm.FeatureNames) != sm.Cols {
+			return fmt.Errorf("特征名数量(%d)与列数(%d)不一致", len(sm.FeatureNames), sm.Cols)
+		}
+		flags |= binaryFlagFeatureNames
+	}
+
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+
+	buf := make([]byte, 0, 64)
+	buf = append(buf, binaryMagic...)
+	version := byte(1)
+	if flags&binaryFlagFeatureNames != 0 {
+		version = binaryVersion
+	}
+	buf = append(buf, version, flags)
+	buf = binary.AppendUvarint(buf, uint64(sm.Rows))
+	buf = binary.AppendUvarint(buf, uint64(sm.Cols))
+	buf = binary.AppendUvarint(buf, uint64(len(sm.Data)))
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+
+	for _, name := range sm.FeatureNames {
+		buf = binary.AppendUvarint(buf[:0], uint64(len(name)))
+		buf = append(buf, name...)
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		buf = binary.AppendUvarint(buf[:0], uint64(len(cols)))
+		prev := 0
+		for _, col := range cols {
+			buf = binary.AppendVarint(buf, int64(col-prev))
+			prev = col
+		}
+		for _, v := range data {
+			if float32Values {
+				buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(v)))
+			} else {
+				buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(float64(v)))
+			}
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := w.Write(binary.LittleEndian.AppendUint32(nil, crc.Sum32()))
+	return err
+}
+
+// ReadBinary 从 r 中读取 WriteBinary 写入的稀疏矩阵，并校验版本和CRC32校验和
+// r 未实现 io.ByteReader 时内部会使用缓冲读取，可能读取超出矩阵末尾的数据
+func ReadBinary(r io.Reader) (*SparseMatrix, error) {
+	return ReadBinaryOf[float64](r)
+}
+
+// ReadBinaryOf 与 ReadBinary 相同，但将元素值读取为指定的类型
+// 例如 ReadBinaryOf[float32] 可以直接得到 SparseMatrix32，无需经过 float64 的中间结果
+func ReadBinaryOf[T Float](r io.Reader) (*SparseMatrixOf[T], error) {
+	br, ok := r.(binaryReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	cr := &checksumReader{r: br, crc: crc32.NewIEEE()}
+
+	header := make([]byte, len(binaryMagic)+2)
+	if _, err := io.ReadFull(cr, header); err != nil {
+		return nil, fmt.Errorf("读取二进制头部失败: %v", err)
+	}
+	if string(header[:len(binaryMagic)]) != binaryMagic {
+		return nil, fmt.Errorf("不是有效的稀疏矩阵二进制数据")
+	}
+	version := header[len(binaryMagic)]
+	if version < 1 || version > binaryVersion {
+		return nil, fmt.Errorf("不支持的二进制格式版本: %d", version)
+	}
+	flags := header[len(binaryMagic)+1]
+	if flags&^binaryKnownFlags != 0 || (version == 1 && flags&binaryFlagFeatureNames != 0) {
+		return nil, fmt.Errorf("未知的二进制格式标志: %#x", flags)
+	}
+
+	var size [3]int
+	for k := range size {
+		n, err := binary.ReadUvarint(cr)
+		if err != nil {
+			return nil, fmt.Errorf("读取矩阵尺寸失败: %v", err)
+		}
+		if n > math.MaxInt {
+			return nil, fmt.Errorf("矩阵尺寸过大: %d", n)
+		}
+		size[k] = int(n)
+	}
+	rows, cols, nnz := size[0], size[1], size[2]
+
+	// 尺寸来自外部数据，预分配的容量设置上限，避免损坏的数据导致过量分配内存
+	const maxPrealloc = 1 << 20
+	sm := &SparseMatrixOf[T]{
+		Rows:   rows,
+		Cols:   cols,
+		Data:   make([]T, 0, min(nnz, maxPrealloc)),
+		RowPtr: make([]int, 1, min(rows, maxPrealloc)+1),
+		ColIdx: make([]int, 0, min(nnz, maxPrealloc)),
+	}
+
+	if flags&binaryFlagFeatureNames != 0 {
+		sm.FeatureNames = make([]string, 0, min(cols, maxPrealloc))
+		for j := 0; j < cols; j++ {
+			n, err := binary.ReadUvarint(cr)
+			if err != nil {
+				return nil, fmt.Errorf("读取第%d个特征名失败: %v", j, err)
+			}
+			if n > binaryMaxNameLen {
+				return nil, fmt.Errorf("第%d个特征名过长: %d", j, n)
+			}
+			name := make([]byte, n)
+			if _, err := io.ReadFull(cr, name); err != nil {
+				return nil, fmt.Errorf("读取第%d个特征名失败: %v", j, err)
+			}
+			sm.FeatureNames = append(sm.FeatureNames, string(name))
+		}
+	}
+
+	valueSize := 8
+	if flags&binaryFlagFloat32 != 0 {
+		valueSize = 4
+	}
+	value := make([]byte, valueSize)
+	for i := 0; i < rows; i++ {
+		n, err := binary.ReadUvarint(cr)
+		if err != nil {
+			return nil, fmt.Errorf("第%d行: 读取非零元素个数失败: %v", i, err)
+		}
+		if n > uint64(nnz-len(sm.Data)) {
+			return nil, fmt.Errorf("第%d行: 非零元素个数超出声明的总数 %d", i, nnz)
+		}
+
+		col := 0
+		for k := uint64(0); k < n; k++ {
+			delta, err := binary.ReadVarint(cr)
+			if err != nil {
+				return nil, fmt.Errorf("第%d行: 读取列索引失败: %v", i, err)
+			}
+			col += int(delta)
+			if col < 0 || col >= cols {
+				return nil, fmt.Errorf("第%d行: 列索引 %d 超出范围 %d", i, col, cols)
+			}
+			sm.ColIdx = append(sm.ColIdx, col)
+		}
+		for k := uint64(0); k < n; k++ {
+			if _, err := io.ReadFull(cr, value); err != nil {
+				return nil, fmt.Errorf("第%d行: 读取元素值失败: %v", i, err)
+			}
+			if valueSize == 4 {
+				sm.Data = append(sm.Data, T(math.Float32frombits(binary.LittleEndian.Uint32(value))))
+			} else {
+				sm.Data = append(sm.Data, T(math.Float64frombits(binary.LittleEndian.Uint64(value))))
+			}
+		}
+		sm.RowPtr = append(sm.RowPtr, len(sm.Data))
+	}
+	if len(sm.Data) != nnz {
+		return nil, fmt.Errorf("非零元素个数(%d)与声明的总数(%d)不一致", len(sm.Data), nnz)
+	}
+
+	// 校验和本身不计入CRC
+	want := cr.crc.Sum32()
+	trailer := make([]byte, 4)
+	if _, err := io.ReadFull(br, trailer); err != nil {
+		return nil, fmt.Errorf("读取校验和失败: %v", err)
+	}
+	if got := binary.LittleEndian.Uint32(trailer); got != want {
+		return nil, fmt.Errorf("校验和不匹配: %08x != %08x", got, want)
+	}
+
+	return sm, nil
+}
+
+// SaveBinary 将稀疏矩阵以二进制格式保存到文件
+func (sm *SparseMatrixOf[T]) SaveBinary(filename string, opts BinaryOptions) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("无法创建文件: %v", err)
+	}
+
+	if err := sm.WriteBinary(file, opts); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadBinary 从文件中读取二进制格式的稀疏矩阵
+func LoadBinary(filename string) (*SparseMatrix, error) {
+	return LoadBinaryOf[float64](filename)
+}
+
+// LoadBinaryOf 从文件中读取二进制格式的稀疏矩阵，并将元素值读取为指定的类型
+func LoadBinaryOf[T Float](filename string) (*SparseMatrixOf[T], error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开文件: %v", err)
+	}
+	defer file.Close()
+
+	return ReadBinaryOf[T](bufio.NewReader(file))
+}
+
+// binaryReader 同时支持按块和按字节读取
+type binaryReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// checksumReader 在读取的同时累计CRC32校验和
+type checksumReader struct {
+	r   binaryReader
+	crc hash.Hash32
+	buf [1]byte // ReadByte 写入校验和时使用，避免每个字节分配一次内存
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.crc.Write(p[:n])
+	return n, err
+}
+
+func (c *checksumReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.buf[0] = b
+		c.crc.Write(c.buf[:])
+	}
+	return b, err
+}
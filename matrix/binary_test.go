@@ -0,0 +1,180 @@
+package matrix
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestBinary_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		sm   *SparseMatrix
+	}{
+		{
+			name: "普通矩阵",
+			sm: DenseToSparse([][]float64{
+				{0.1, 0, 0, 1e-300},
+				{0, 0, 0, 0},
+				{-2, 0, math.MaxFloat64, 0},
+			}),
+		},
+		{
+			name: "大列索引",
+			sm: &SparseMatrix{
+				Rows:   2,
+				Cols:   1 << 40,
+				Data:   []float64{1, 2, 3},
+				RowPtr: []int{0, 2, 3},
+				ColIdx: []int{5, 1<<40 - 1, 0},
+			},
+		},
+		{
+			name: "空矩阵",
+			sm:   &SparseMatrix{Rows: 3, Cols: 2, Data: []float64{}, RowPtr: []int{0, 0, 0, 0}, ColIdx: []int{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.sm.WriteBinary(&buf, BinaryOptions{}); err != nil {
+				t.Fatalf("WriteBinary() error = %v", err)
+			}
+			got, err := ReadBinary(&buf)
+			if err != nil {
+				t.Fatalf("ReadBinary() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.sm) {
+				t.Errorf("ReadBinary() = %+v, want %+v", got, tt.sm)
+			}
+		})
+	}
+}
+
+func TestBinary_Float32(t *testing.T) {
+	sm := DenseToSparse([][]float64{{0.1, 0, 3}, {0, 1.0 / 3, 0}})
+
+	var f64, f32 bytes.Buffer
+	if err := sm.WriteBinary(&f64, BinaryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.WriteBinary(&f32, BinaryOptions{Float32: true}); err != nil {
+		t.Fatal(err)
+	}
+	if f32.Len() != f64.Len()-4*sm.NNZ() {
+		t.Errorf("float32 格式长度 = %d, want %d", f32.Len(), f64.Len()-4*sm.NNZ())
+	}
+
+	got, err := ReadBinary(&f32)
+	if err != nil {
+		t.Fatalf("ReadBinary() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.ColIdx, sm.ColIdx) || !reflect.DeepEqual(got.RowPtr, sm.RowPtr) {
+		t.Errorf("ReadBinary() 结构 = %+v, want %+v", got, sm)
+	}
+	for k, v := range sm.Data {
+		if got.Data[k] != float64(float32(v)) {
+			t.Errorf("Data[%d] = %v, want %v", k, got.Data[k], float64(float32(v)))
+		}
+	}
+}
+
+// TestBinary_Stream 验证同一个流中可以连续读写多个矩阵
+func TestBinary_Stream(t *testing.T) {
+	a := DenseToSparse([][]float64{{1, 0}, {0, 2}})
+	b := DenseToSparse([][]float64{{0, 0, 3}})
+
+	var buf bytes.Buffer
+	for _, sm := range []*SparseMatrix{a, b} {
+		if err := sm.WriteBinary(&buf, BinaryOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, want := range []*SparseMatrix{a, b} {
+		got, err := ReadBinary(&buf)
+		if err != nil {
+			t.Fatalf("ReadBinary() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ReadBinary() = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestBinary_SaveLoad(t *testing.T) {
+	sm := DenseToSparse([][]float64{{0, 2.5, 0}, {-1, 0, 4}})
+
+	tmpfile, err := os.CreateTemp("", "binary_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	defer os.Remove(tmpfile.Name())
+
+	if err := sm.SaveBinary(tmpfile.Name(), BinaryOptions{}); err != nil {
+		t.Fatalf("SaveBinary() error = %v", err)
+	}
+	loaded, err := LoadBinary(tmpfile.Name())
+	if err != nil {
+		t.Fatalf("LoadBinary() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, sm) {
+		t.Errorf("LoadBinary() = %+v, want %+v", loaded, sm)
+	}
+}
+
+func TestBinary_Errors(t *testing.T) {
+	sm := DenseToSparse([][]float64{{1, 0, 2}, {0, 3, 0}})
+	var buf bytes.Buffer
+	if err := sm.WriteBinary(&buf, BinaryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+	if version := valid[4]; version != 1 {
+		t.Fatalf("不带特征名时版本应为1, 得到 %d", version)
+	}
+
+	// modify 复制一份合法数据并修改其中的内容
+	modify := func(fn func(b []byte) []byte) []byte {
+		return fn(append([]byte(nil), valid...))
+	}
+
+	inputs := map[string][]byte{
+		"魔数错误":    modify(func(b []byte) []byte { b[0] = 'X'; return b }),
+		"版本不支持":   modify(func(b []byte) []byte { b[4] = binaryVersion + 1; return b }),
+		"未知标志":    modify(func(b []byte) []byte { b[5] = 0x80; return b }),
+		"版本1带特征名": modify(func(b []byte) []byte { b[5] |= binaryFlagFeatureNames; return b }),
+		"数据损坏":    modify(func(b []byte) []byte { b[len(b)-6] ^= 0xff; return b }),
+		"校验和错误":   modify(func(b []byte) []byte { b[len(b)-1] ^= 0xff; return b }),
+		"数据截断":    modify(func(b []byte) []byte { return b[:len(b)-5] }),
+		"空数据":     {},
+	}
+	for name, input := range inputs {
+		if _, err := ReadBinary(bytes.NewReader(input)); err == nil {
+			t.Errorf("ReadBinary() %s 时应返回错误", name)
+		}
+	}
+}
+
+func TestBinary_SparseMatrix32(t *testing.T) {
+	sm := DenseToSparse([][]float32{{0.1, 0, 3}, {0, 1.0 / 3, 0}})
+
+	var buf bytes.Buffer
+	if err := sm.WriteBinary(&buf, BinaryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if flags := buf.Bytes()[5]; flags&binaryFlagFloat32 == 0 {
+		t.Errorf("SparseMatrix32 应以float32存储，flags = %#x", flags)
+	}
+
+	got, err := ReadBinaryOf[float32](&buf)
+	if err != nil {
+		t.Fatalf("ReadBinaryOf() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, sm) {
+		t.Errorf("ReadBinaryOf() = %+v, want %+v", got, sm)
+	}
+}
@@ -0,0 +1,345 @@
+package matrix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// defaultChunkRows 是每个块默认包含的行数
+const defaultChunkRows = 1 << 16
+
+// ChunkedOptions 分块构建稀疏矩阵时的选项
+type ChunkedOptions struct {
+	ChunkRows    int           // 每个块的行数，小于等于0时默认为65536
+	SpillDir     string        // 非空时，写满的块以二进制格式写入该目录下的临时文件，内存中只保留当前块
+	Binary       BinaryOptions // 写入临时文件时的二进制格式选项
+	FeatureNames []string      // 可选的特征名，长度必须等于列数
+}
+
+// ChunkedBuilder 逐行追加数据，按固定行数将矩阵切分为多个块
+// 适合无法一次性放入内存的语料：配合 SpillDir 使用时，内存占用只与单个块的大小有关
+type ChunkedBuilder struct {
+	cols    int
+	opts    ChunkedOptions
+	current *SparseMatrix // 正在写入的块
+	chunks  []matrixChunk // 已完成的块
+	rows    int           // 已追加的总行数
+	done    bool          // 是否已调用 Finish
+}
+
+// matrixChunk 表示一个已完成的块，保存在内存中或磁盘上
+type matrixChunk struct {
+	rows int
+	path string        // 溢写的文件路径，为空时数据保存在 sm 中
+	sm   *SparseMatrix // 内存中的块
+}
+
+// NewChunkedBuilder 创建列数为 cols 的分块构建器
+func NewChunkedBuilder(cols int, opts ChunkedOptions) (*ChunkedBuilder, error) {
+	if cols < 0 {
+		return nil, fmt.Errorf("无效的列数: %d", cols)
+	}
+	if opts.FeatureNames != nil && len(opts.FeatureNames) != cols {
+		return nil, fmt.Errorf("特征名数量(%d)与列数(%d)不一致", len(opts.FeatureNames), cols)
+	}
+	if opts.ChunkRows <= 0 {
+		opts.ChunkRows = defaultChunkRows
+	}
+	opts.FeatureNames = copyNames(opts.FeatureNames)
+
+	return &ChunkedBuilder{
+		cols:    cols,
+		opts:    opts,
+		current: NewSparseMatrix(0, cols),
+	}, nil
+}
+
+// AppendRow 追加一行数据，cols 和 data 为该行非零元素的列索引和值
+// 列索引可以无序，但不能重复；数据会被复制，调用后可以复用传入的切片
+func (b *ChunkedBuilder) AppendRow(cols []int, data []float64) error {
+	if b.done {
+		return fmt.Errorf("构建器已完成，不能再追加数据")
+	}
+	if len(cols) != len(data) {
+		return fmt.Errorf("列索引数量(%d)与值的数量(%d)不一致", len(cols), len(data))
+	}
+	for _, col := range cols {
+		if col < 0 || col >= b.cols {
+			return fmt.Errorf("第%d行: 列索引 %d 超出范围 %d", b.rows, col, b.cols)
+		}
+	}
+
+	sm := b.current
+	start := len(sm.Data)
+	sm.ColIdx = append(sm.ColIdx, cols...)
+	sm.Data = append(sm.Data, data...)
+	sortRow(sm.ColIdx[start:], sm.Data[start:])
+	for k := start + 1; k < len(sm.ColIdx); k++ {
+		if col := sm.ColIdx[k]; col == sm.ColIdx[k-1] {
+			sm.ColIdx, sm.Data = sm.ColIdx[:start], sm.Data[:start]
+			return fmt.Errorf("第%d行: 列索引 %d 重复", b.rows, col)
+		}
+	}
+	sm.RowPtr = append(sm.RowPtr, len(sm.Data))
+	sm.Rows++
+	b.rows++
+
+	if sm.Rows >= b.opts.ChunkRows {
+		return b.flush()
+	}
+	return nil
+}
+
+// Rows 返回已追加的总行数
+func (b *ChunkedBuilder) Rows() int {
+	return b.rows
+}
+
+// Finish 完成构建，返回分块矩阵；之后不能再追加数据
+func (b *ChunkedBuilder) Finish() (*ChunkedMatrix, error) {
+	if b.done {
+		return nil, fmt.Errorf("构建器已完成")
+	}
+	if b.current.Rows > 0 {
+		if err := b.flush(); err != nil {
+			return nil, err
+		}
+	}
+	b.done = true
+	b.current = nil
+
+	return &ChunkedMatrix{
+		Rows:         b.rows,
+		Cols:         b.cols,
+		FeatureNames: b.opts.FeatureNames,
+		chunks:       b.chunks,
+	}, nil
+}
+
+// Abort 放弃构建并删除已溢写到磁盘的临时文件，之后不能再追加数据
+// 构建过程中出错时应调用 Abort 清理；调用 Finish 成功后临时文件归 ChunkedMatrix 所有，Abort 不做任何事
+func (b *ChunkedBuilder) Abort() error {
+	if b.done {
+		return nil
+	}
+	b.done = true
+	b.current = nil
+
+	var firstErr error
+	for _, chunk := range b.chunks {
+		if chunk.path == "" {
+			continue
+		}
+		if err := os.Remove(chunk.path); err != nil && !os.IsNotExist(err) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	b.chunks = nil
+	return firstErr
+}
+
+// flush 将当前块保存到内存或磁盘，并开始一个新块
+func (b *ChunkedBuilder) flush() error {
+	sm := b.current
+	chunk := matrixChunk{rows: sm.Rows, sm: sm}
+
+	if b.opts.SpillDir != "" {
+		file, err := os.CreateTemp(b.opts.SpillDir, "chunk-*.spm")
+		if err != nil {
+			return fmt.Errorf("无法创建临时文件: %v", err)
+		}
+		bw := bufio.NewWriter(file)
+		err = sm.WriteBinary(bw, b.opts.Binary)
+		if err == nil {
+			err = bw.Flush()
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(file.Name())
+			return fmt.Errorf("无法写入块: %v", err)
+		}
+		chunk = matrixChunk{rows: sm.Rows, path: file.Name()}
+	}
+
+	b.chunks = append(b.chunks, chunk)
+	b.current = NewSparseMatrix(0, b.cols)
+	return nil
+}
+
+// ChunkedMatrix 表示按行切分为多个块的稀疏矩阵，块可能保存在内存中或磁盘上
+// 通过 Chunk 或 Iterator 逐块读取，同一时间只需在内存中保留一个块
+type ChunkedMatrix struct {
+	Rows         int      // 总行数
+	Cols         int      // 列数
+	FeatureNames []string // 可选的特征名，会附加到读取出的每个块上
+
+	chunks []matrixChunk
+}
+
+// NumChunks 返回块的数量
+func (cm *ChunkedMatrix) NumChunks() int {
+	return len(cm.chunks)
+}
+
+// Chunk 返回第k个块，溢写到磁盘的块会从文件中读取
+// 内存中的块直接返回，调用方不应修改它
+func (cm *ChunkedMatrix) Chunk(k int) (*SparseMatrix, error) {
+	if k < 0 || k >= len(cm.chunks) {
+		return nil, fmt.Errorf("块索引 %d 超出范围 %d", k, len(cm.chunks))
+	}
+
+	chunk := cm.chunks[k]
+	sm := chunk.sm
+	if chunk.path != "" {
+		var err error
+		if sm, err = LoadBinary(chunk.path); err != nil {
+			return nil, fmt.Errorf("读取第%d个块失败: %v", k, err)
+		}
+		if sm.Rows != chunk.rows || sm.Cols != cm.Cols {
+			return nil, fmt.Errorf("第%d个块的尺寸(%d, %d)与预期(%d, %d)不一致", k, sm.Rows, sm.Cols, chunk.rows, cm.Cols)
+		}
+	}
+	if sm.FeatureNames == nil {
+		sm.FeatureNames = cm.FeatureNames
+	}
+	return sm, nil
+}
+
+// ChunkStart 返回第k个块的第一行在整个矩阵中的行号
+func (cm *ChunkedMatrix) ChunkStart(k int) int {
+	start := 0
+	for _, chunk := range cm.chunks[:k] {
+		start += chunk.rows
+	}
+	return start
+}
+
+// ToSparse 将所有块合并为一个稀疏矩阵，要求整个矩阵能够放入内存
+func (cm *ChunkedMatrix) ToSparse() (*SparseMatrix, error) {
+	if len(cm.chunks) == 0 {
+		sm := NewSparseMatrix(0, cm.Cols)
+		sm.FeatureNames = copyNames(cm.FeatureNames)
+		return sm, nil
+	}
+
+	matrices := make([]*SparseMatrix, len(cm.chunks))
+	for k := range cm.chunks {
+		sm, err := cm.Chunk(k)
+		if err != nil {
+			return nil, err
+		}
+		matrices[k] = sm
+	}
+	return MergeMultipleSparseMatrixRows(matrices...)
+}
+
+// WriteLibSVM 逐块将矩阵以LibSVM格式写入 w，参数含义与 SparseMatrix.WriteLibSVM 相同
+// 每次只读取一个块，适合导出无法一次性放入内存的矩阵
+func (cm *ChunkedMatrix) WriteLibSVM(w io.Writer, labels []int, zeroBase bool, precision int) error {
+	if len(labels) != cm.Rows {
+		return fmt.Errorf("标签数量(%d)与矩阵行数(%d)不匹配", len(labels), cm.Rows)
+	}
+
+	lw := NewLibSVMWriter(w, zeroBase, precision)
+	it := cm.Iterator()
+	for it.Next() {
+		cols, data := it.Row()
+		if err := lw.WriteRow(strconv.Itoa(labels[it.Index()]), cols, data); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return lw.Flush()
+}
+
+// Iterator 返回逐行遍历所有块的迭代器
+func (cm *ChunkedMatrix) Iterator() *RowIterator {
+	return &RowIterator{cm: cm, chunk: -1}
+}
+
+// Remove 删除溢写到磁盘的临时文件，之后不能再读取这些块
+func (cm *ChunkedMatrix) Remove() error {
+	var firstErr error
+	for _, chunk := range cm.chunks {
+		if chunk.path == "" {
+			continue
+		}
+		if err := os.Remove(chunk.path); err != nil && !os.IsNotExist(err) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// RowIterator 逐行遍历分块矩阵，每次只在内存中保留一个块
+//
+//	it := cm.Iterator()
+//	for it.Next() {
+//		cols, data := it.Row()
+//		...
+//	}
+//	if err := it.Err(); err != nil { ... }
+type RowIterator struct {
+	cm    *ChunkedMatrix
+	chunk int           // 当前块的索引
+	sm    *SparseMatrix // 当前块
+	row   int           // 当前行在块内的行号
+	index int           // 当前行在整个矩阵中的行号
+	err   error
+}
+
+// Next 前进到下一行，没有更多的行或发生错误时返回false
+func (it *RowIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.sm != nil && it.row+1 < it.sm.Rows {
+		it.row++
+		it.index++
+		return true
+	}
+
+	// 跳到下一个非空的块
+	for it.chunk+1 < len(it.cm.chunks) {
+		it.chunk++
+		sm, err := it.cm.Chunk(it.chunk)
+		if err != nil {
+			it.err = err
+			it.sm = nil
+			return false
+		}
+		if sm.Rows == 0 {
+			continue
+		}
+		if it.sm != nil {
+			it.index++
+		}
+		it.sm = sm
+		it.row = 0
+		return true
+	}
+	it.sm = nil
+	return false
+}
+
+// Row 返回当前行非零元素的列索引和值，返回的切片在迭代器前进后可能失效
+func (it *RowIterator) Row() ([]int, []float64) {
+	return it.sm.Row(it.row)
+}
+
+// Index 返回当前行在整个矩阵中的行号
+func (it *RowIterator) Index() int {
+	return it.index
+}
+
+// Err 返回迭代过程中发生的错误
+func (it *RowIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,217 @@
+package matrix
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestChunkedBuilder(t *testing.T) {
+	sm := newNamedTestSparse()
+
+	tests := []struct {
+		name       string
+		opts       ChunkedOptions
+		wantChunks int
+	}{
+		{name: "内存", opts: ChunkedOptions{ChunkRows: 2}, wantChunks: 3},
+		{name: "溢写", opts: ChunkedOptions{ChunkRows: 2, SpillDir: t.TempDir()}, wantChunks: 3},
+		{name: "单块", opts: ChunkedOptions{SpillDir: t.TempDir()}, wantChunks: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.FeatureNames = sm.FeatureNames
+			b, err := NewChunkedBuilder(sm.Cols, tt.opts)
+			if err != nil {
+				t.Fatalf("NewChunkedBuilder() error = %v", err)
+			}
+			for i := 0; i < sm.Rows; i++ {
+				cols, data := sm.Row(i)
+				if err := b.AppendRow(cols, data); err != nil {
+					t.Fatalf("AppendRow() error = %v", err)
+				}
+			}
+			cm, err := b.Finish()
+			if err != nil {
+				t.Fatalf("Finish() error = %v", err)
+			}
+			defer cm.Remove()
+
+			if cm.Rows != sm.Rows || cm.NumChunks() != tt.wantChunks {
+				t.Fatalf("Rows = %d, NumChunks() = %d, want %d, %d", cm.Rows, cm.NumChunks(), sm.Rows, tt.wantChunks)
+			}
+			if tt.opts.SpillDir != "" {
+				entries, _ := os.ReadDir(tt.opts.SpillDir)
+				if len(entries) != tt.wantChunks {
+					t.Errorf("临时文件数量 = %d, want %d", len(entries), tt.wantChunks)
+				}
+			}
+
+			got, err := cm.ToSparse()
+			if err != nil {
+				t.Fatalf("ToSparse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, sm) {
+				t.Errorf("ToSparse() = %+v, want %+v", got, sm)
+			}
+
+			// 逐行遍历的结果应与原矩阵相同
+			n := 0
+			it := cm.Iterator()
+			for it.Next() {
+				cols, data := it.Row()
+				wantCols, wantData := sm.Row(n)
+				if it.Index() != n || !reflect.DeepEqual(cols, wantCols) || !reflect.DeepEqual(data, wantData) {
+					t.Errorf("第%d行: Index() = %d, Row() = %v, %v, want %v, %v", n, it.Index(), cols, data, wantCols, wantData)
+				}
+				n++
+			}
+			if it.Err() != nil || n != sm.Rows {
+				t.Errorf("遍历了 %d 行, Err() = %v, want %d 行", n, it.Err(), sm.Rows)
+			}
+
+			if start := cm.ChunkStart(cm.NumChunks() - 1); start != 4 && tt.wantChunks == 3 {
+				t.Errorf("ChunkStart() = %d, want 4", start)
+			}
+		})
+	}
+}
+
+func TestChunkedBuilder_AppendRow(t *testing.T) {
+	b, err := NewChunkedBuilder(4, ChunkedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 无序的列索引会被排序，传入的切片可以复用
+	cols, data := []int{3, 0}, []float64{2, 1}
+	if err := b.AppendRow(cols, data); err != nil {
+		t.Fatalf("AppendRow() error = %v", err)
+	}
+	cols[0], data[0] = 1, 5
+	if err := b.AppendRow(nil, nil); err != nil {
+		t.Fatalf("AppendRow() error = %v", err)
+	}
+
+	if err := b.AppendRow([]int{1, 4}, []float64{1, 1}); err == nil {
+		t.Error("AppendRow() 列索引越界时应返回错误")
+	}
+	if err := b.AppendRow([]int{1, 1}, []float64{1, 1}); err == nil {
+		t.Error("AppendRow() 列索引重复时应返回错误")
+	}
+	if err := b.AppendRow([]int{1}, nil); err == nil {
+		t.Error("AppendRow() 长度不一致时应返回错误")
+	}
+
+	cm, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := cm.ToSparse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := DenseToSparse([][]float64{{1, 0, 0, 2}, {0, 0, 0, 0}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToSparse() = %+v, want %+v", got, want)
+	}
+	if err := b.AppendRow(nil, nil); err == nil {
+		t.Error("Finish() 之后 AppendRow() 应返回错误")
+	}
+
+	if _, err := NewChunkedBuilder(2, ChunkedOptions{FeatureNames: []string{"a"}}); err == nil {
+		t.Error("NewChunkedBuilder() 特征名数量不匹配时应返回错误")
+	}
+}
+
+func TestChunkedBuilder_Abort(t *testing.T) {
+	dir := t.TempDir()
+	b, err := NewChunkedBuilder(3, ChunkedOptions{ChunkRows: 1, SpillDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := b.AppendRow([]int{i}, []float64{1}); err != nil {
+			t.Fatalf("AppendRow() error = %v", err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 3 {
+		t.Fatalf("溢写的文件数量不匹配: 期望 3, 得到 %d", len(entries))
+	}
+
+	if err := b.Abort(); err != nil {
+		t.Fatalf("Abort() error = %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Abort() 之后仍有 %d 个临时文件", len(entries))
+	}
+	if err := b.AppendRow([]int{0}, []float64{1}); err == nil {
+		t.Error("Abort() 之后 AppendRow() 应返回错误")
+	}
+	if _, err := b.Finish(); err == nil {
+		t.Error("Abort() 之后 Finish() 应返回错误")
+	}
+
+	// Finish 之后的 Abort 不删除 ChunkedMatrix 的文件
+	b, err = NewChunkedBuilder(3, ChunkedOptions{ChunkRows: 1, SpillDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AppendRow([]int{1}, []float64{2}); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Abort(); err != nil {
+		t.Fatalf("Abort() error = %v", err)
+	}
+	if _, err := cm.ToSparse(); err != nil {
+		t.Errorf("Finish() 之后调用 Abort() 不应影响 ChunkedMatrix: %v", err)
+	}
+	cm.Remove()
+}
+
+func TestChunkedMatrix_WriteLibSVM(t *testing.T) {
+	sm := newTestSparse()
+	labels := []int{0, 1, 0, 1, 1}
+
+	b, err := NewChunkedBuilder(sm.Cols, ChunkedOptions{ChunkRows: 2, SpillDir: t.TempDir(), Binary: BinaryOptions{Float32: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < sm.Rows; i++ {
+		if err := b.AppendRow(sm.Row(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cm, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want bytes.Buffer
+	if err := cm.WriteLibSVM(&got, labels, false, 3); err != nil {
+		t.Fatalf("WriteLibSVM() error = %v", err)
+	}
+	if err := sm.WriteLibSVM(&want, labels, false, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("WriteLibSVM() = %q, want %q", got.String(), want.String())
+	}
+
+	if err := cm.WriteLibSVM(&got, labels[:1], false, 3); err == nil {
+		t.Error("WriteLibSVM() 标签数量不匹配时应返回错误")
+	}
+
+	// 删除临时文件后无法再读取
+	if err := cm.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	it := cm.Iterator()
+	if it.Next() || it.Err() == nil {
+		t.Error("Remove() 之后遍历应返回错误")
+	}
+}
@@ -0,0 +1,125 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+)
+
+// COOMatrixOf 以坐标(COO)格式表示稀疏矩阵，即(行, 列, 值)三元组列表
+// 元素可以按任意顺序追加，适合增量构建；计算前应通过ToCSR转换为SparseMatrixOf
+type COOMatrixOf[T Float] struct {
+	Rows   int   // 矩阵的行数
+	Cols   int   // 矩阵的列数
+	Data   []T   // 非零元素值
+	RowIdx []int // 非零元素的行索引
+	ColIdx []int // 非零元素的列索引
+}
+
+// COOMatrix 是元素值为 float64 的COO矩阵
+type COOMatrix = COOMatrixOf[float64]
+
+// NewCOOMatrix 创建一个指定行列数的空COO矩阵
+func NewCOOMatrix(rows, cols int) *COOMatrix {
+	return NewCOOMatrixOf[float64](rows, cols)
+}
+
+// NewCOOMatrixOf 创建一个指定元素类型和行列数的空COO矩阵
+func NewCOOMatrixOf[T Float](rows, cols int) *COOMatrixOf[T] {
+	return &COOMatrixOf[T]{
+		Rows: rows,
+		Cols: cols,
+	}
+}
+
+// Append 追加一个(行, 列, 值)三元组，索引超出矩阵范围时返回错误，矩阵保持不变
+func (c *COOMatrixOf[T]) Append(row, col int, value T) error {
+	if row < 0 || row >= c.Rows || col < 0 || col >= c.Cols {
+		return fmt.Errorf("索引(%d, %d)超出矩阵范围(%d, %d)", row, col, c.Rows, c.Cols)
+	}
+	c.RowIdx = append(c.RowIdx, row)
+	c.ColIdx = append(c.ColIdx, col)
+	c.Data = append(c.Data, value)
+	return nil
+}
+
+// ToCSR 将COO矩阵转换为CSR格式的SparseMatrix
+// 元素按行分桶后在行内按列索引稳定排序，重复的(行, 列)元素会原样保留；
+// 要求所有索引都在矩阵范围内，通过 Append 追加的元素总能满足
+func (c *COOMatrixOf[T]) ToCSR() *SparseMatrixOf[T] {
+	nnz := len(c.Data)
+	sm := &SparseMatrixOf[T]{
+		Rows:   c.Rows,
+		Cols:   c.Cols,
+		Data:   make([]T, nnz),
+		RowPtr: make([]int, c.Rows+1),
+		ColIdx: make([]int, nnz),
+	}
+
+	// 统计每行的元素个数，并累加得到行指针
+	for _, row := range c.RowIdx {
+		sm.RowPtr[row+1]++
+	}
+	for i := 0; i < c.Rows; i++ {
+		sm.RowPtr[i+1] += sm.RowPtr[i]
+	}
+
+	// 按行分桶，同一行内保持原有的相对顺序
+	next := make([]int, c.Rows)
+	copy(next, sm.RowPtr[:c.Rows])
+	for k, row := range c.RowIdx {
+		pos := next[row]
+		sm.ColIdx[pos] = c.ColIdx[k]
+		sm.Data[pos] = c.Data[k]
+		next[row]++
+	}
+
+	// 行内按列索引排序
+	for i := 0; i < c.Rows; i++ {
+		sortRow(sm.ColIdx[sm.RowPtr[i]:sm.RowPtr[i+1]], sm.Data[sm.RowPtr[i]:sm.RowPtr[i+1]])
+	}
+
+	return sm
+}
+
+// ToCOO 将CSR格式的稀疏矩阵转换为COO格式
+// 三元组按行优先、行内按列的顺序排列
+func (sm *SparseMatrixOf[T]) ToCOO() *COOMatrixOf[T] {
+	nnz := len(sm.Data)
+	c := &COOMatrixOf[T]{
+		Rows:   sm.Rows,
+		Cols:   sm.Cols,
+		Data:   make([]T, 0, nnz),
+		RowIdx: make([]int, 0, nnz),
+		ColIdx: make([]int, 0, nnz),
+	}
+
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			c.Append(i, col, data[k])
+		}
+	}
+
+	return c
+}
+
+// rowSorter 用于对一行的列索引和值同时排序
+type rowSorter[T Float] struct {
+	cols []int
+	data []T
+}
+
+func (r rowSorter[T]) Len() int           { return len(r.cols) }
+func (r rowSorter[T]) Less(i, j int) bool { return r.cols[i] < r.cols[j] }
+func (r rowSorter[T]) Swap(i, j int) {
+	r.cols[i], r.cols[j] = r.cols[j], r.cols[i]
+	r.data[i], r.data[j] = r.data[j], r.data[i]
+}
+
+// sortRow 按列索引对一行元素进行稳定排序，已有序时直接返回
+func sortRow[T Float](cols []int, data []T) {
+	if sort.IntsAreSorted(cols) {
+		return
+	}
+	sort.Stable(rowSorter[T]{cols: cols, data: data})
+}
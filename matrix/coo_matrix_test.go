@@ -0,0 +1,69 @@
+package matrix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCOOMatrix_ToCSR(t *testing.T) {
+	coo := NewCOOMatrix(3, 4)
+	coo.Append(2, 3, 5)
+	coo.Append(0, 2, 2)
+	coo.Append(2, 0, 4)
+	coo.Append(0, 1, 1)
+	coo.Append(2, 3, 6) // 重复元素保留，并保持追加顺序
+
+	got := coo.ToCSR()
+	want := &SparseMatrix{
+		Rows:   3,
+		Cols:   4,
+		Data:   []float64{1, 2, 4, 5, 6},
+		RowPtr: []int{0, 2, 2, 5},
+		ColIdx: []int{1, 2, 0, 3, 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToCSR() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCOOMatrix_AppendOutOfRange(t *testing.T) {
+	coo := NewCOOMatrix(2, 3)
+	for _, idx := range [][2]int{{2, 0}, {0, 3}, {-1, 0}, {0, -1}} {
+		if err := coo.Append(idx[0], idx[1], 1); err == nil {
+			t.Errorf("Append(%d, %d) 索引越界时应返回错误", idx[0], idx[1])
+		}
+	}
+	if err := coo.Append(1, 2, 1); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	// 越界的元素不会被追加，ToCSR 不会越界访问
+	want := DenseToSparse([][]float64{{0, 0, 0}, {0, 0, 1}})
+	if got := coo.ToCSR(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToCSR() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSparseMatrix_ToCOO(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{0, 1, 0},
+		{2, 0, 3},
+	})
+
+	coo := sm.ToCOO()
+	want := &COOMatrix{
+		Rows:   2,
+		Cols:   3,
+		Data:   []float64{1, 2, 3},
+		RowIdx: []int{0, 1, 1},
+		ColIdx: []int{1, 0, 2},
+	}
+	if !reflect.DeepEqual(coo, want) {
+		t.Errorf("ToCOO() = %+v, want %+v", coo, want)
+	}
+
+	// COO -> CSR 往返后应与原矩阵一致
+	if back := coo.ToCSR(); !reflect.DeepEqual(back, sm) {
+		t.Errorf("ToCOO().ToCSR() = %+v, want %+v", back, sm)
+	}
+}
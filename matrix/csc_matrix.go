@@ -0,0 +1,156 @@
+package matrix
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// 编译期检查 CSCMatrix 是否实现了 gonum 的相关接口
+var (
+	_ mat.Matrix         = (*CSCMatrix)(nil)
+	_ mat.ColNonZeroDoer = (*CSCMatrix)(nil)
+)
+
+// CSCMatrixOf 以CSC(Compressed Sparse Column)格式表示稀疏矩阵
+// 第j列的非零元素为 Data[ColPtr[j]:ColPtr[j+1]]，对应的行索引为 RowIdx[ColPtr[j]:ColPtr[j+1]]，
+// 每列内的行索引按升序排列。适合特征选择、列统计、坐标下降等逐列（逐特征）处理的算法
+type CSCMatrixOf[T Float] struct {
+	Rows   int   // 矩阵的行数
+	Cols   int   // 矩阵的列数
+	Data   []T   // 非零元素值，按列依次存放
+	ColPtr []int // 列指针，长度为Cols+1，ColPtr[j]为第j列第一个非零元素在Data中的位置
+	RowIdx []int // 非零元素的行索引
+
+	FeatureNames []string // 可选的特征名，含义与 SparseMatrixOf.FeatureNames 相同
+}
+
+// CSCMatrix 是元素值为 float64 的CSC矩阵
+type CSCMatrix = CSCMatrixOf[float64]
+
+// ToCSC 将CSR格式的稀疏矩阵转换为CSC格式，时间复杂度为O(nnz+Cols)
+func (sm *SparseMatrixOf[T]) ToCSC() *CSCMatrixOf[T] {
+	// A 的CSC存储与 Aᵀ 的CSR存储完全相同
+	t := sm.Transpose()
+	return &CSCMatrixOf[T]{
+		Rows:         sm.Rows,
+		Cols:         sm.Cols,
+		Data:         t.Data,
+		ColPtr:       t.RowPtr,
+		RowIdx:       t.ColIdx,
+		FeatureNames: copyNames(sm.FeatureNames),
+	}
+}
+
+// ToCSC 将COO矩阵转换为CSC格式
+// 元素按列分桶后在列内按行索引稳定排序，重复的(行, 列)元素会原样保留
+func (c *COOMatrixOf[T]) ToCSC() *CSCMatrixOf[T] {
+	// 交换行列后转换为CSR，即得到原矩阵的CSC存储
+	t := (&COOMatrixOf[T]{
+		Rows:   c.Cols,
+		Cols:   c.Rows,
+		Data:   c.Data,
+		RowIdx: c.ColIdx,
+		ColIdx: c.RowIdx,
+	}).ToCSR()
+	return &CSCMatrixOf[T]{
+		Rows:   c.Rows,
+		Cols:   c.Cols,
+		Data:   t.Data,
+		ColPtr: t.RowPtr,
+		RowIdx: t.ColIdx,
+	}
+}
+
+// ToCSR 将CSC矩阵转换为CSR格式的稀疏矩阵，时间复杂度为O(nnz+Rows)
+func (c *CSCMatrixOf[T]) ToCSR() *SparseMatrixOf[T] {
+	sm := c.transposed().Transpose()
+	sm.FeatureNames = copyNames(c.FeatureNames)
+	return sm
+}
+
+// ToCOO 将CSC矩阵转换为COO格式，三元组按列优先、列内按行的顺序排列
+func (c *CSCMatrixOf[T]) ToCOO() *COOMatrixOf[T] {
+	nnz := len(c.Data)
+	coo := &COOMatrixOf[T]{
+		Rows:   c.Rows,
+		Cols:   c.Cols,
+		Data:   make([]T, 0, nnz),
+		RowIdx: make([]int, 0, nnz),
+		ColIdx: make([]int, 0, nnz),
+	}
+	for j := 0; j < c.Cols; j++ {
+		rows, data := c.Col(j)
+		for k, row := range rows {
+			coo.Append(row, j, data[k])
+		}
+	}
+	return coo
+}
+
+// NNZ 返回矩阵中存储的非零元素个数
+func (c *CSCMatrixOf[T]) NNZ() int {
+	return len(c.Data)
+}
+
+// Col 返回第j列非零元素的行索引和值
+// 返回的切片直接引用矩阵的底层存储，时间复杂度为O(1)，修改它们会影响矩阵本身
+func (c *CSCMatrixOf[T]) Col(j int) ([]int, []T) {
+	if len(c.ColPtr) == 0 {
+		return nil, nil
+	}
+	start, end := c.ColPtr[j], c.ColPtr[j+1]
+	return c.RowIdx[start:end:end], c.Data[start:end:end]
+}
+
+// Dims 返回矩阵的行数和列数，实现 mat.Matrix 接口
+func (c *CSCMatrixOf[T]) Dims() (r, cols int) {
+	return c.Rows, c.Cols
+}
+
+// At 返回第i行第j列的元素值，实现 mat.Matrix 接口
+// 行索引可能无序，因此逐个扫描第j列的元素；若存在重复的(行, 列)元素，返回它们的和
+func (c *CSCMatrixOf[T]) At(i, j int) float64 {
+	if uint(i) >= uint(c.Rows) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(c.Cols) {
+		panic(mat.ErrColAccess)
+	}
+
+	rows, data := c.Col(j)
+	v := 0.0
+	for k, row := range rows {
+		if row == i {
+			v += float64(data[k])
+		}
+	}
+	return v
+}
+
+// T 返回矩阵的隐式转置，实现 mat.Matrix 接口
+func (c *CSCMatrixOf[T]) T() mat.Matrix {
+	return mat.Transpose{Matrix: c}
+}
+
+// DoColNonZero 对第j列的每个非零元素调用 fn，实现 mat.ColNonZeroDoer 接口
+func (c *CSCMatrixOf[T]) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if uint(j) >= uint(c.Cols) {
+		panic(mat.ErrColAccess)
+	}
+	rows, data := c.Col(j)
+	for k, row := range rows {
+		if data[k] != 0 {
+			fn(row, j, float64(data[k]))
+		}
+	}
+}
+
+// transposed 将CSC存储直接解释为转置矩阵的CSR存储，不复制数据
+func (c *CSCMatrixOf[T]) transposed() *SparseMatrixOf[T] {
+	return &SparseMatrixOf[T]{
+		Rows:   c.Cols,
+		Cols:   c.Rows,
+		Data:   c.Data,
+		RowPtr: c.ColPtr,
+		ColIdx: c.RowIdx,
+	}
+}
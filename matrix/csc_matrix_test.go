@@ -0,0 +1,116 @@
+package matrix
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSparseMatrix_ToCSC(t *testing.T) {
+	sm := newTestSparse()
+	csc := sm.ToCSC()
+
+	want := &CSCMatrix{
+		Rows:   5,
+		Cols:   4,
+		Data:   []float64{1, 5, 3, 7, 2, 8, 4, 6},
+		ColPtr: []int{0, 2, 4, 6, 8},
+		RowIdx: []int{0, 3, 2, 4, 0, 4, 2, 3},
+	}
+	if !reflect.DeepEqual(csc, want) {
+		t.Errorf("ToCSC() = %+v, want %+v", csc, want)
+	}
+	if !mat.Equal(csc, sm) {
+		t.Errorf("ToCSC() = %v, want %v", mat.Formatted(csc), mat.Formatted(sm))
+	}
+
+	if back := csc.ToCSR(); !reflect.DeepEqual(back, sm) {
+		t.Errorf("ToCSR() = %+v, want %+v", back, sm)
+	}
+}
+
+func TestCSCMatrix_Col(t *testing.T) {
+	csc := newTestSparse().ToCSC()
+
+	tests := []struct {
+		col      int
+		wantRows []int
+		wantData []float64
+	}{
+		{col: 0, wantRows: []int{0, 3}, wantData: []float64{1, 5}},
+		{col: 2, wantRows: []int{0, 4}, wantData: []float64{2, 8}},
+		{col: 3, wantRows: []int{2, 3}, wantData: []float64{4, 6}},
+	}
+	for _, tt := range tests {
+		rows, data := csc.Col(tt.col)
+		if !reflect.DeepEqual(rows, tt.wantRows) || !reflect.DeepEqual(data, tt.wantData) {
+			t.Errorf("Col(%d) = %v, %v, want %v, %v", tt.col, rows, data, tt.wantRows, tt.wantData)
+		}
+	}
+
+	var got []float64
+	csc.DoColNonZero(1, func(i, j int, v float64) {
+		got = append(got, float64(i), v)
+	})
+	if want := []float64{2, 3, 4, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DoColNonZero(1) = %v, want %v", got, want)
+	}
+
+	empty := &CSCMatrix{Rows: 2, Cols: 2}
+	if rows, data := empty.Col(1); len(rows) != 0 || len(data) != 0 {
+		t.Errorf("Col(1) = %v, %v, want 空", rows, data)
+	}
+}
+
+func TestCSCMatrix_AtUnsortedCol(t *testing.T) {
+	// 列内行索引无序时，At 仍应返回正确的值
+	csc := &CSCMatrix{
+		Rows:   3,
+		Cols:   1,
+		Data:   []float64{5, 7},
+		ColPtr: []int{0, 2},
+		RowIdx: []int{2, 0},
+	}
+	for i, want := range []float64{7, 0, 5} {
+		if got := csc.At(i, 0); got != want {
+			t.Errorf("At(%d, 0) = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestCOOMatrix_ToCSC(t *testing.T) {
+	coo := NewCOOMatrixOf[float32](3, 2)
+	coo.Append(2, 1, 4)
+	coo.Append(0, 1, 2)
+	coo.Append(1, 0, 3)
+	coo.Append(0, 1, 1)
+
+	csc := coo.ToCSC()
+	// 列内按行排序，重复元素保持原有的相对顺序
+	want := &CSCMatrixOf[float32]{
+		Rows:   3,
+		Cols:   2,
+		Data:   []float32{3, 2, 1, 4},
+		ColPtr: []int{0, 1, 4},
+		RowIdx: []int{1, 0, 0, 2},
+	}
+	if !reflect.DeepEqual(csc, want) {
+		t.Errorf("ToCSC() = %+v, want %+v", csc, want)
+	}
+	if v := csc.At(0, 1); v != 3 {
+		t.Errorf("At(0, 1) = %v, want 3", v)
+	}
+
+	back := csc.ToCOO()
+	wantCOO := &COOMatrixOf[float32]{
+		Rows:   3,
+		Cols:   2,
+		Data:   []float32{3, 2, 1, 4},
+		RowIdx: []int{1, 0, 0, 2},
+		ColIdx: []int{0, 1, 1, 1},
+	}
+	if !reflect.DeepEqual(back, wantCOO) {
+		t.Errorf("ToCOO() = %+v, want %+v", back, wantCOO)
+	}
+}
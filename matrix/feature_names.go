@@ -0,0 +1,77 @@
+package matrix
+
+import "fmt"
+
+// SetFeatureNames 设置矩阵的特征名，names 的长度必须等于矩阵列数；传入nil表示清除特征名
+// 特征名会被复制，之后修改 names 不会影响矩阵
+func (sm *SparseMatrixOf[T]) SetFeatureNames(names []string) error {
+	if names != nil && len(names) != sm.Cols {
+		return fmt.Errorf("特征名数量(%d)与列数(%d)不一致", len(names), sm.Cols)
+	}
+	sm.FeatureNames = copyNames(names)
+	return nil
+}
+
+// FeatureIndex 返回特征名对应的列索引，矩阵没有特征名或找不到时返回false
+// 特征名重复时返回第一个匹配的列，时间复杂度为O(Cols)
+func (sm *SparseMatrixOf[T]) FeatureIndex(name string) (int, bool) {
+	for j, feature := range sm.FeatureNames {
+		if feature == name {
+			return j, true
+		}
+	}
+	return -1, false
+}
+
+// FeatureName 返回第j列的特征名，矩阵没有特征名时返回空字符串
+func (sm *SparseMatrixOf[T]) FeatureName(j int) string {
+	if sm.FeatureNames == nil {
+		return ""
+	}
+	return sm.FeatureNames[j]
+}
+
+// copyNames 复制特征名，nil 保持为nil
+func copyNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(names)), names...)
+}
+
+// equalNames 判断两组特征名是否相同
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// concatNames 按列拼接多个矩阵的特征名
+// 所有矩阵都没有特征名时返回nil，否则没有特征名的矩阵对应的列名为空字符串
+func concatNames[T Float](matrices []*SparseMatrixOf[T]) []string {
+	named := false
+	total := 0
+	for _, matrix := range matrices {
+		named = named || matrix.FeatureNames != nil
+		total += matrix.Cols
+	}
+	if !named {
+		return nil
+	}
+
+	names := make([]string, 0, total)
+	for _, matrix := range matrices {
+		if matrix.FeatureNames != nil {
+			names = append(names, matrix.FeatureNames...)
+		} else {
+			names = append(names, make([]string, matrix.Cols)...)
+		}
+	}
+	return names
+}
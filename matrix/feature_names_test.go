@@ -0,0 +1,151 @@
+package matrix
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func newNamedTestSparse() *SparseMatrix {
+	sm := newTestSparse()
+	sm.FeatureNames = []string{"a", "b", "c", "d"}
+	return sm
+}
+
+func TestSparseMatrix_FeatureNames(t *testing.T) {
+	sm := newTestSparse()
+	if err := sm.SetFeatureNames([]string{"a", "b"}); err == nil {
+		t.Error("SetFeatureNames() 数量不匹配时应返回错误")
+	}
+
+	names := []string{"a", "b", "c", "b"}
+	if err := sm.SetFeatureNames(names); err != nil {
+		t.Fatalf("SetFeatureNames() error = %v", err)
+	}
+	names[0] = "x"
+	if sm.FeatureName(0) != "a" {
+		t.Error("SetFeatureNames() 应复制特征名")
+	}
+
+	if j, ok := sm.FeatureIndex("b"); !ok || j != 1 {
+		t.Errorf("FeatureIndex(b) = %d, %v, want 1, true", j, ok)
+	}
+	if _, ok := sm.FeatureIndex("x"); ok {
+		t.Error("FeatureIndex(x) 应返回false")
+	}
+
+	sm.FeatureNames = []string{"a"}
+	if err := sm.Validate(); err == nil {
+		t.Error("Validate() 特征名数量不匹配时应返回错误")
+	}
+}
+
+func TestSparseMatrix_FeatureNamesMergeCols(t *testing.T) {
+	named := DenseToSparse([][]float64{{1, 0}, {0, 2}})
+	named.FeatureNames = []string{"x", "y"}
+	unnamed := DenseToSparse([][]float64{{3}, {0}})
+
+	merged, err := MergeMultipleSparseMatrixCols(named, unnamed, named)
+	if err != nil {
+		t.Fatalf("MergeMultipleSparseMatrixCols() error = %v", err)
+	}
+	if want := []string{"x", "y", "", "x", "y"}; !reflect.DeepEqual(merged.FeatureNames, want) {
+		t.Errorf("FeatureNames = %q, want %q", merged.FeatureNames, want)
+	}
+
+	merged, err = MergeMultipleSparseMatrixCols(unnamed, unnamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.FeatureNames != nil {
+		t.Errorf("FeatureNames = %q, want nil", merged.FeatureNames)
+	}
+
+	named.AddConstantFeature(1)
+	if want := []string{"x", "y", ""}; !reflect.DeepEqual(named.FeatureNames, want) {
+		t.Errorf("AddConstantFeature() FeatureNames = %q, want %q", named.FeatureNames, want)
+	}
+}
+
+func TestSparseMatrix_FeatureNamesMergeRows(t *testing.T) {
+	a := DenseToSparse([][]float64{{1, 0}})
+	a.FeatureNames = []string{"x", "y"}
+	b := DenseToSparse([][]float64{{0, 2}})
+
+	merged, err := MergeMultipleSparseMatrixRows(b, a)
+	if err != nil {
+		t.Fatalf("MergeMultipleSparseMatrixRows() error = %v", err)
+	}
+	if want := []string{"x", "y"}; !reflect.DeepEqual(merged.FeatureNames, want) {
+		t.Errorf("FeatureNames = %q, want %q", merged.FeatureNames, want)
+	}
+
+	b.FeatureNames = []string{"y", "x"}
+	if _, err := MergeMultipleSparseMatrixRows(a, b); err == nil {
+		t.Error("MergeMultipleSparseMatrixRows() 特征名不一致时应返回错误")
+	}
+}
+
+func TestSparseMatrix_FeatureNamesSelect(t *testing.T) {
+	sm := newNamedTestSparse()
+
+	cols, err := sm.SelectCols([]int{3, 1, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"d", "b", "d"}; !reflect.DeepEqual(cols.FeatureNames, want) {
+		t.Errorf("SelectCols() FeatureNames = %q, want %q", cols.FeatureNames, want)
+	}
+
+	rows, err := sm.SelectRows([]int{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slice, err := sm.SliceRows(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*SparseMatrix{rows, slice, sm.ToFloat32().ToFloat64(), sm.ToCSC().ToCSR()} {
+		if !reflect.DeepEqual(m.FeatureNames, sm.FeatureNames) {
+			t.Errorf("FeatureNames = %q, want %q", m.FeatureNames, sm.FeatureNames)
+		}
+	}
+
+	// 结果的特征名不与原矩阵共享
+	rows.FeatureNames[0] = "z"
+	if sm.FeatureNames[0] != "a" {
+		t.Error("SelectRows() 的特征名不应与原矩阵共享")
+	}
+}
+
+func TestSparseMatrix_FeatureNamesSerialization(t *testing.T) {
+	sm := newNamedTestSparse()
+	sm.FeatureNames[1] = "编程 语言"
+
+	var buf bytes.Buffer
+	if err := sm.WriteBinary(&buf, BinaryOptions{}); err != nil {
+		t.Fatalf("WriteBinary() error = %v", err)
+	}
+	if version := buf.Bytes()[4]; version != 2 {
+		t.Errorf("带有特征名时版本应为2, 得到 %d", version)
+	}
+	got, err := ReadBinary(&buf)
+	if err != nil {
+		t.Fatalf("ReadBinary() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, sm) {
+		t.Errorf("ReadBinary() = %+v, want %+v", got, sm)
+	}
+
+	buf.Reset()
+	if err := sm.WriteNPZ(&buf, true); err != nil {
+		t.Fatalf("WriteNPZ() error = %v", err)
+	}
+	got, err = ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("ReadNPZ() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.FeatureNames, sm.FeatureNames) {
+		t.Errorf("ReadNPZ() FeatureNames = %q, want %q", got.FeatureNames, sm.FeatureNames)
+	}
+}
@@ -0,0 +1,284 @@
+package matrix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/yinziyang/mlkit/label_encoder"
+)
+
+// LibSVMIndexBase 表示LibSVM数据中特征索引的起始值
+type LibSVMIndexBase int
+
+const (
+	IndexBaseAuto LibSVMIndexBase = iota // 自动推断：数据中出现索引0时视为从0开始，否则视为从1开始
+	IndexBaseZero                        // 特征索引从0开始，对应 ConvertToLibSVM 的 zeroBase=true
+	IndexBaseOne                         // 特征索引从1开始，LibSVM/SVMlight 的默认约定
+)
+
+// LibSVMReadOptions 读取LibSVM数据时的选项
+type LibSVMReadOptions struct {
+	IndexBase   LibSVMIndexBase // 特征索引的起始值
+	NumFeatures int             // 特征维度，小于等于0时由数据中出现的最大特征索引推断
+	MultiLabel  bool            // 标签是否为逗号分隔的多标签，例如 "1,3"
+}
+
+// LibSVMData 表示从LibSVM数据中读取的结果
+type LibSVMData struct {
+	X           *SparseMatrix // 特征矩阵，特征索引统一转换为从0开始
+	Labels      []float64     // 每行的标签，MultiLabel 为 true 时为nil
+	MultiLabels [][]float64   // 每行的标签列表，仅 MultiLabel 为 true 时有效
+	QueryIDs    []int64       // 每行的qid，数据中没有qid字段时为nil，缺少qid的行为0
+}
+
+// ReadLibSVM 从 io.Reader 中读取LibSVM/SVMlight格式的数据
+// 每行格式为 "<label> [qid:<id>] <index>:<value> ... [# comment]"，
+// 以 '#' 开头的注释和空行会被忽略；同一行内的特征索引可以无序，但不能重复
+func ReadLibSVM(r io.Reader, opts LibSVMReadOptions) (*LibSVMData, error) {
+	result := &LibSVMData{}
+	X := NewSparseMatrix(0, 0)
+
+	hasZero := false
+	hasQID := false
+	maxIndex := -1
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<30)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if pos := strings.IndexByte(line, '#'); pos >= 0 {
+			line = line[:pos]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// 解析标签；多标签模式下标签字段可以为空
+		if opts.MultiLabel {
+			var labels []float64
+			if !strings.Contains(fields[0], ":") {
+				for _, s := range strings.Split(fields[0], ",") {
+					if s == "" {
+						continue
+					}
+					label, err := strconv.ParseFloat(s, 64)
+					if err != nil {
+						return nil, fmt.Errorf("第%d行: 无法解析标签 %q: %v", lineNo, s, err)
+					}
+					labels = append(labels, label)
+				}
+				fields = fields[1:]
+			}
+			result.MultiLabels = append(result.MultiLabels, labels)
+		} else {
+			label, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				return nil, fmt.Errorf("第%d行: 无法解析标签 %q: %v", lineNo, fields[0], err)
+			}
+			result.Labels = append(result.Labels, label)
+			fields = fields[1:]
+		}
+
+		// 解析qid和特征
+		var qid int64
+		rowStart := len(X.Data)
+		for _, field := range fields {
+			key, value, ok := strings.Cut(field, ":")
+			if !ok {
+				return nil, fmt.Errorf("第%d行: 无效的特征 %q", lineNo, field)
+			}
+			if key == "qid" {
+				id, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("第%d行: 无法解析qid %q: %v", lineNo, value, err)
+				}
+				qid = id
+				hasQID = true
+				continue
+			}
+
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("第%d行: 无效的特征索引 %q", lineNo, key)
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("第%d行: 无法解析特征值 %q: %v", lineNo, value, err)
+			}
+			if index == 0 {
+				hasZero = true
+			}
+			if index > maxIndex {
+				maxIndex = index
+			}
+			X.ColIdx = append(X.ColIdx, index)
+			X.Data = append(X.Data, v)
+		}
+		result.QueryIDs = append(result.QueryIDs, qid)
+
+		// 行内按特征索引排序，并检查重复索引
+		cols, data := X.ColIdx[rowStart:], X.Data[rowStart:]
+		sortRow(cols, data)
+		for k := 1; k < len(cols); k++ {
+			if cols[k] == cols[k-1] {
+				return nil, fmt.Errorf("第%d行: 特征索引 %d 重复", lineNo, cols[k])
+			}
+		}
+		X.RowPtr = append(X.RowPtr, len(X.Data))
+		X.Rows++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取LibSVM数据失败: %v", err)
+	}
+
+	// 将特征索引统一转换为从0开始
+	oneBased := opts.IndexBase == IndexBaseOne || (opts.IndexBase == IndexBaseAuto && !hasZero)
+	if oneBased {
+		if hasZero {
+			return nil, fmt.Errorf("特征索引应从1开始，但出现了索引0")
+		}
+		for k := range X.ColIdx {
+			X.ColIdx[k]--
+		}
+		if maxIndex >= 0 {
+			maxIndex--
+		}
+	}
+
+	// 确定特征维度
+	X.Cols = maxIndex + 1
+	if opts.NumFeatures > 0 {
+		if opts.NumFeatures < X.Cols {
+			return nil, fmt.Errorf("特征维度(%d)小于数据中的最大特征索引(%d)", opts.NumFeatures, maxIndex)
+		}
+		X.Cols = opts.NumFeatures
+	}
+
+	if !hasQID {
+		result.QueryIDs = nil
+	}
+	result.X = X
+	return result, nil
+}
+
+// LoadLibSVM 从文件中读取LibSVM/SVMlight格式的数据
+func LoadLibSVM(filename string, opts LibSVMReadOptions) (*LibSVMData, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开文件: %v", err)
+	}
+	defer file.Close()
+
+	return ReadLibSVM(file, opts)
+}
+
+// LibSVMWriter 以流式方式将稀疏数据逐行写入LibSVM格式
+// 内部带有缓冲，写入完成后必须调用 Flush
+type LibSVMWriter struct {
+	w         *bufio.Writer
+	zeroBase  bool   // 特征索引是否从0开始
+	precision int    // 特征值保留的小数位数
+	buf       []byte // 行缓冲，避免逐行分配内存
+}
+
+// NewLibSVMWriter 创建LibSVM格式的流式写入器
+// zeroBase: 特征索引是否从0开始
+// precision: 特征值保留的小数位数，小于0时默认保留6位；值为1的特征直接输出 index:1
+func NewLibSVMWriter(w io.Writer, zeroBase bool, precision int) *LibSVMWriter {
+	if precision < 0 {
+		precision = 6 // 默认保留6位小数
+	}
+	return &LibSVMWriter{
+		w:         bufio.NewWriter(w),
+		zeroBase:  zeroBase,
+		precision: precision,
+	}
+}
+
+// WriteRow 写入一行数据
+// label: 已格式化的标签，多标签可写为 "1,3"
+// cols, data: 该行非零元素的列索引（应按升序排列）和值
+func (lw *LibSVMWriter) WriteRow(label string, cols []int, data []float64) error {
+	return writeLibSVMRow(lw, label, cols, data)
+}
+
+// writeLibSVMRow 与 WriteRow 相同，但支持 float32 元素值，float32 按其自身精度格式化
+func writeLibSVMRow[T Float](lw *LibSVMWriter, label string, cols []int, data []T) error {
+	buf := append(lw.buf[:0], label...)
+	for k, col := range cols {
+		featureIndex := col
+		if !lw.zeroBase {
+			featureIndex++
+		}
+		buf = append(buf, ' ')
+		buf = strconv.AppendInt(buf, int64(featureIndex), 10)
+		buf = append(buf, ':')
+		// 对于值为1的特征，直接输出 index:1
+		if data[k] == 1.0 {
+			buf = append(buf, '1')
+		} else {
+			buf = strconv.AppendFloat(buf, float64(data[k]), 'f', lw.precision, floatBits[T]())
+		}
+	}
+	buf = append(buf, '\n')
+	lw.buf = buf
+
+	_, err := lw.w.Write(buf)
+	return err
+}
+
+// Flush 将缓冲区中的数据写入底层的 io.Writer
+func (lw *LibSVMWriter) Flush() error {
+	return lw.w.Flush()
+}
+
+// WriteLibSVM 将稀疏矩阵以LibSVM格式逐行写入 w，每行以换行符结尾
+// labels 为整数标签，zeroBase 和 precision 的含义与 ConvertToLibSVM 相同
+func (sm *SparseMatrixOf[T]) WriteLibSVM(w io.Writer, labels []int, zeroBase bool, precision int) error {
+	if len(labels) != sm.Rows {
+		return fmt.Errorf("标签数量(%d)与矩阵行数(%d)不匹配", len(labels), sm.Rows)
+	}
+	return sm.writeLibSVM(w, zeroBase, precision, func(i int) string {
+		return strconv.Itoa(labels[i])
+	})
+}
+
+// WriteLibSVMFloat 将稀疏矩阵以LibSVM格式逐行写入 w，使用浮点数标签（如回归任务的目标值）
+// 标签以能够精确还原的最短形式输出
+func (sm *SparseMatrixOf[T]) WriteLibSVMFloat(w io.Writer, labels []float64, zeroBase bool, precision int) error {
+	if len(labels) != sm.Rows {
+		return fmt.Errorf("标签数量(%d)与矩阵行数(%d)不匹配", len(labels), sm.Rows)
+	}
+	return sm.writeLibSVM(w, zeroBase, precision, func(i int) string {
+		return strconv.FormatFloat(labels[i], 'g', -1, 64)
+	})
+}
+
+// WriteLibSVMWithEncoder 将稀疏矩阵以LibSVM格式逐行写入 w
+// 字符串标签通过已训练的 LabelEncoder 转换为整数后输出
+func (sm *SparseMatrixOf[T]) WriteLibSVMWithEncoder(w io.Writer, labels []string, encoder *label_encoder.LabelEncoder, zeroBase bool, precision int) error {
+	encoded, err := encoder.Transform(labels)
+	if err != nil {
+		return fmt.Errorf("标签编码失败: %v", err)
+	}
+	return sm.WriteLibSVM(w, encoded, zeroBase, precision)
+}
+
+// writeLibSVM 逐行写入矩阵，label 返回第i行的标签字符串
+func (sm *SparseMatrixOf[T]) writeLibSVM(w io.Writer, zeroBase bool, precision int, label func(i int) string) error {
+	lw := NewLibSVMWriter(w, zeroBase, precision)
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		if err := writeLibSVMRow(lw, label(i), cols, data); err != nil {
+			return err
+		}
+	}
+	return lw.Flush()
+}
@@ -0,0 +1,243 @@
+package matrix
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yinziyang/mlkit/label_encoder"
+)
+
+func TestReadLibSVM(t *testing.T) {
+	input := `# 注释行
+1 1:0.5 3:2
+0 2:1 # 行尾注释
+
+-1 3:1.5 1:4
+`
+	data, err := ReadLibSVM(strings.NewReader(input), LibSVMReadOptions{})
+	if err != nil {
+		t.Fatalf("ReadLibSVM() error = %v", err)
+	}
+
+	wantX := &SparseMatrix{
+		Rows:   3,
+		Cols:   3,
+		Data:   []float64{0.5, 2, 1, 4, 1.5},
+		RowPtr: []int{0, 2, 3, 5},
+		ColIdx: []int{0, 2, 1, 0, 2},
+	}
+	if !reflect.DeepEqual(data.X, wantX) {
+		t.Errorf("ReadLibSVM() X = %+v, want %+v", data.X, wantX)
+	}
+	if want := []float64{1, 0, -1}; !reflect.DeepEqual(data.Labels, want) {
+		t.Errorf("ReadLibSVM() Labels = %v, want %v", data.Labels, want)
+	}
+	if data.QueryIDs != nil {
+		t.Errorf("ReadLibSVM() QueryIDs = %v, want nil", data.QueryIDs)
+	}
+}
+
+func TestReadLibSVM_Options(t *testing.T) {
+	input := "1 1:1 2:2\n0 2:3\n"
+
+	// 显式指定从0开始
+	data, err := ReadLibSVM(strings.NewReader(input), LibSVMReadOptions{IndexBase: IndexBaseZero, NumFeatures: 5})
+	if err != nil {
+		t.Fatalf("ReadLibSVM() error = %v", err)
+	}
+	if data.X.Cols != 5 || !reflect.DeepEqual(data.X.ColIdx, []int{1, 2, 2}) {
+		t.Errorf("ReadLibSVM() zero-based Cols = %d, ColIdx = %v", data.X.Cols, data.X.ColIdx)
+	}
+
+	// 特征维度小于最大索引
+	if _, err := ReadLibSVM(strings.NewReader(input), LibSVMReadOptions{NumFeatures: 1}); err == nil {
+		t.Error("ReadLibSVM() 特征维度过小时应返回错误")
+	}
+
+	// 指定从1开始但出现索引0
+	if _, err := ReadLibSVM(strings.NewReader("1 0:1\n"), LibSVMReadOptions{IndexBase: IndexBaseOne}); err == nil {
+		t.Error("ReadLibSVM() 从1开始的数据中出现索引0时应返回错误")
+	}
+
+	// 重复的特征索引
+	if _, err := ReadLibSVM(strings.NewReader("1 1:1 1:2\n"), LibSVMReadOptions{}); err == nil {
+		t.Error("ReadLibSVM() 特征索引重复时应返回错误")
+	}
+
+	// 无效的特征
+	if _, err := ReadLibSVM(strings.NewReader("1 abc\n"), LibSVMReadOptions{}); err == nil {
+		t.Error("ReadLibSVM() 特征格式无效时应返回错误")
+	}
+}
+
+// TestLoadLibSVM_Sklearn 读取 scikit-learn 的 dump_svmlight_file 生成的数据
+// 测试数据由 testdata/libsvm_fixtures.py 生成
+func TestLoadLibSVM_Sklearn(t *testing.T) {
+	wantX := &SparseMatrix{
+		Rows:   4,
+		Cols:   4,
+		Data:   []float64{0.5, 1.25, 3, 0.1, -2e-07},
+		RowPtr: []int{0, 2, 3, 3, 5},
+		ColIdx: []int{0, 2, 1, 0, 3},
+	}
+
+	data, err := LoadLibSVM("testdata/sklearn_qid.svmlight", LibSVMReadOptions{})
+	if err != nil {
+		t.Fatalf("LoadLibSVM() error = %v", err)
+	}
+	if !reflect.DeepEqual(data.X, wantX) {
+		t.Errorf("LoadLibSVM() X = %+v, want %+v", data.X, wantX)
+	}
+	if want := []float64{1, 0, 2, 1}; !reflect.DeepEqual(data.Labels, want) {
+		t.Errorf("LoadLibSVM() Labels = %v, want %v", data.Labels, want)
+	}
+	if want := []int64{7, 7, 8, 8}; !reflect.DeepEqual(data.QueryIDs, want) {
+		t.Errorf("LoadLibSVM() QueryIDs = %v, want %v", data.QueryIDs, want)
+	}
+
+	data, err = LoadLibSVM("testdata/sklearn_multilabel.svmlight", LibSVMReadOptions{IndexBase: IndexBaseOne, MultiLabel: true})
+	if err != nil {
+		t.Fatalf("LoadLibSVM() error = %v", err)
+	}
+	if !reflect.DeepEqual(data.X, wantX) {
+		t.Errorf("LoadLibSVM() multilabel X = %+v, want %+v", data.X, wantX)
+	}
+	wantLabels := [][]float64{{1, 3}, {0}, {2}, {0, 1, 2}}
+	if !reflect.DeepEqual(data.MultiLabels, wantLabels) {
+		t.Errorf("LoadLibSVM() MultiLabels = %v, want %v", data.MultiLabels, wantLabels)
+	}
+	if data.Labels != nil {
+		t.Errorf("LoadLibSVM() multilabel Labels = %v, want nil", data.Labels)
+	}
+}
+
+// TestLibSVM_RoundTrip 验证 ConvertToLibSVM 的输出可以被 ReadLibSVM 原样读回
+func TestLibSVM_RoundTrip(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{0, 1, 0.25, 0},
+		{3.5, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, -0.125},
+	})
+	labels := []int{1, 0, 2, 1}
+
+	for _, zeroBase := range []bool{true, false} {
+		text, err := sm.ConvertToLibSVM(labels, zeroBase, 3)
+		if err != nil {
+			t.Fatalf("ConvertToLibSVM() error = %v", err)
+		}
+
+		base := IndexBaseOne
+		if zeroBase {
+			base = IndexBaseZero
+		}
+		data, err := ReadLibSVM(strings.NewReader(text), LibSVMReadOptions{IndexBase: base, NumFeatures: sm.Cols})
+		if err != nil {
+			t.Fatalf("ReadLibSVM() error = %v", err)
+		}
+		if !reflect.DeepEqual(data.X, sm) {
+			t.Errorf("zeroBase=%v: 读回的矩阵 = %+v, want %+v", zeroBase, data.X, sm)
+		}
+		if want := []float64{1, 0, 2, 1}; !reflect.DeepEqual(data.Labels, want) {
+			t.Errorf("zeroBase=%v: 读回的标签 = %v, want %v", zeroBase, data.Labels, want)
+		}
+	}
+}
+
+func TestSparseMatrix_WriteLibSVM(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{0, 1, 0.5},
+		{2, 0, 0},
+		{0, 0, 0},
+	})
+
+	var buf bytes.Buffer
+	if err := sm.WriteLibSVM(&buf, []int{1, 0, 1}, true, -1); err != nil {
+		t.Fatalf("WriteLibSVM() error = %v", err)
+	}
+	if want := "1 1:1 2:0.500000\n0 0:2.000000\n1\n"; buf.String() != want {
+		t.Errorf("WriteLibSVM() = %q, want %q", buf.String(), want)
+	}
+
+	// ConvertToLibSVM 与流式写入的结果一致，只是末尾没有换行符
+	text, err := sm.ConvertToLibSVM([]int{1, 0, 1}, true, -1)
+	if err != nil {
+		t.Fatalf("ConvertToLibSVM() error = %v", err)
+	}
+	if text+"\n" != buf.String() {
+		t.Errorf("ConvertToLibSVM() = %q, want %q", text, strings.TrimSuffix(buf.String(), "\n"))
+	}
+
+	if err := sm.WriteLibSVM(&buf, []int{1}, true, -1); err == nil {
+		t.Error("WriteLibSVM() 标签数量不匹配时应返回错误")
+	}
+}
+
+func TestSparseMatrix_WriteLibSVMFloat(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{0, 1.5},
+		{2, 0},
+	})
+	labels := []float64{0.1, -3.25}
+
+	var buf bytes.Buffer
+	if err := sm.WriteLibSVMFloat(&buf, labels, false, 2); err != nil {
+		t.Fatalf("WriteLibSVMFloat() error = %v", err)
+	}
+	if want := "0.1 2:1.50\n-3.25 1:2.00\n"; buf.String() != want {
+		t.Errorf("WriteLibSVMFloat() = %q, want %q", buf.String(), want)
+	}
+
+	data, err := ReadLibSVM(&buf, LibSVMReadOptions{IndexBase: IndexBaseOne})
+	if err != nil {
+		t.Fatalf("ReadLibSVM() error = %v", err)
+	}
+	if !reflect.DeepEqual(data.Labels, labels) || !reflect.DeepEqual(data.X, sm) {
+		t.Errorf("读回的数据不一致: X = %+v, Labels = %v", data.X, data.Labels)
+	}
+}
+
+func TestSparseMatrix_WriteLibSVMWithEncoder(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{1, 0},
+		{0, 2},
+		{0, 0},
+	})
+	labels := []string{"spam", "ham", "spam"}
+
+	encoder := label_encoder.New()
+	if err := encoder.Fit(labels); err != nil {
+		t.Fatalf("Fit() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := sm.WriteLibSVMWithEncoder(&buf, labels, encoder, true, 1); err != nil {
+		t.Fatalf("WriteLibSVMWithEncoder() error = %v", err)
+	}
+	if want := "1 0:1\n0 1:2.0\n1\n"; buf.String() != want {
+		t.Errorf("WriteLibSVMWithEncoder() = %q, want %q", buf.String(), want)
+	}
+
+	if err := sm.WriteLibSVMWithEncoder(&buf, []string{"spam", "eggs", "ham"}, encoder, true, 1); err == nil {
+		t.Error("WriteLibSVMWithEncoder() 出现未知标签时应返回错误")
+	}
+}
+
+func TestLibSVMWriter_WriteRow(t *testing.T) {
+	var buf bytes.Buffer
+	lw := NewLibSVMWriter(&buf, false, 3)
+	if err := lw.WriteRow("1,3", []int{0, 4}, []float64{1, 0.25}); err != nil {
+		t.Fatalf("WriteRow() error = %v", err)
+	}
+	if err := lw.WriteRow("2", nil, nil); err != nil {
+		t.Fatalf("WriteRow() error = %v", err)
+	}
+	if err := lw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if want := "1,3 1:1 5:0.250\n2\n"; buf.String() != want {
+		t.Errorf("WriteRow() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,253 @@
+package matrix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MatrixMarketField 表示Matrix Market文件中元素值的类型
+type MatrixMarketField string
+
+const (
+	MatrixMarketReal    MatrixMarketField = "real"    // 实数
+	MatrixMarketInteger MatrixMarketField = "integer" // 整数
+	MatrixMarketPattern MatrixMarketField = "pattern" // 只记录非零位置，值均视为1
+)
+
+// MatrixMarketSymmetry 表示Matrix Market文件的对称性限定符
+type MatrixMarketSymmetry string
+
+const (
+	MatrixMarketGeneral   MatrixMarketSymmetry = "general"   // 存储所有元素
+	MatrixMarketSymmetric MatrixMarketSymmetry = "symmetric" // 只存储下三角（含对角线）元素
+)
+
+// MatrixMarketOptions 写入Matrix Market文件时的选项
+type MatrixMarketOptions struct {
+	Field    MatrixMarketField    // 元素值类型，为空时使用 real
+	Symmetry MatrixMarketSymmetry // 对称性，为空时使用 general
+	Comment  string               // 写在头部之后的注释，可以包含多行
+}
+
+// ReadMatrixMarket 从 io.Reader 中读取coordinate格式的Matrix Market数据
+// 与 scipy.io.mmread 兼容，支持 real/integer/pattern 类型以及 general/symmetric 限定符；
+// symmetric 矩阵中的非对角元素会同时填充到对称位置
+func ReadMatrixMarket(r io.Reader) (*SparseMatrix, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	// 解析头部
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("读取Matrix Market数据失败: %v", err)
+		}
+		return nil, fmt.Errorf("Matrix Market数据为空")
+	}
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != "%%matrixmarket" || header[1] != "matrix" {
+		return nil, fmt.Errorf("无效的Matrix Market头部: %q", scanner.Text())
+	}
+	if header[2] != "coordinate" {
+		return nil, fmt.Errorf("不支持的Matrix Market格式: %s", header[2])
+	}
+	field := MatrixMarketField(header[3])
+	if field != MatrixMarketReal && field != MatrixMarketInteger && field != MatrixMarketPattern {
+		return nil, fmt.Errorf("不支持的Matrix Market元素类型: %s", header[3])
+	}
+	symmetry := MatrixMarketSymmetry(header[4])
+	if symmetry != MatrixMarketGeneral && symmetry != MatrixMarketSymmetric {
+		return nil, fmt.Errorf("不支持的Matrix Market对称性: %s", header[4])
+	}
+
+	var coo *COOMatrix
+	entries := 0
+	lineNo := 1
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		fields := strings.Fields(line)
+
+		// 第一行非注释内容为矩阵尺寸
+		if coo == nil {
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("第%d行: 无效的尺寸行 %q", lineNo, line)
+			}
+			var size [3]int
+			for k, f := range fields {
+				n, err := strconv.Atoi(f)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("第%d行: 无效的尺寸 %q", lineNo, f)
+				}
+				size[k] = n
+			}
+			if symmetry == MatrixMarketSymmetric && size[0] != size[1] {
+				return nil, fmt.Errorf("对称矩阵的行数(%d)与列数(%d)不相等", size[0], size[1])
+			}
+			coo = NewCOOMatrix(size[0], size[1])
+			entries = size[2]
+			continue
+		}
+
+		wantFields := 3
+		if field == MatrixMarketPattern {
+			wantFields = 2
+		}
+		if len(fields) != wantFields {
+			return nil, fmt.Errorf("第%d行: 元素应包含%d个字段，得到 %q", lineNo, wantFields, line)
+		}
+		row, err := strconv.Atoi(fields[0])
+		if err != nil || row < 1 || row > coo.Rows {
+			return nil, fmt.Errorf("第%d行: 无效的行索引 %q", lineNo, fields[0])
+		}
+		col, err := strconv.Atoi(fields[1])
+		if err != nil || col < 1 || col > coo.Cols {
+			return nil, fmt.Errorf("第%d行: 无效的列索引 %q", lineNo, fields[1])
+		}
+
+		value := 1.0
+		switch field {
+		case MatrixMarketReal:
+			if value, err = strconv.ParseFloat(fields[2], 64); err != nil {
+				return nil, fmt.Errorf("第%d行: 无法解析元素值 %q: %v", lineNo, fields[2], err)
+			}
+		case MatrixMarketInteger:
+			n, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("第%d行: 无法解析整数元素值 %q: %v", lineNo, fields[2], err)
+			}
+			value = float64(n)
+		}
+
+		// Matrix Market的索引从1开始
+		coo.Append(row-1, col-1, value)
+		if symmetry == MatrixMarketSymmetric && row != col {
+			coo.Append(col-1, row-1, value)
+		}
+		entries--
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取Matrix Market数据失败: %v", err)
+	}
+	if coo == nil {
+		return nil, fmt.Errorf("Matrix Market数据缺少尺寸行")
+	}
+	if entries != 0 {
+		return nil, fmt.Errorf("元素数量与尺寸行声明的不一致，相差 %d", entries)
+	}
+
+	return coo.ToCSR(), nil
+}
+
+// LoadMatrixMarket 从文件中读取Matrix Market格式的稀疏矩阵
+func LoadMatrixMarket(filename string) (*SparseMatrix, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开文件: %v", err)
+	}
+	defer file.Close()
+
+	return ReadMatrixMarket(file)
+}
+
+// WriteMatrixMarket 将稀疏矩阵以coordinate格式的Matrix Market数据写入 w
+// 元素按行优先顺序输出，与 scipy.io.mmwrite 兼容：
+// - integer 类型要求所有元素均为整数
+// - pattern 类型只输出非零位置
+// - symmetric 要求矩阵为对称方阵，只输出下三角（含对角线）元素
+func (sm *SparseMatrixOf[T]) WriteMatrixMarket(w io.Writer, opts MatrixMarketOptions) error {
+	field := opts.Field
+	if field == "" {
+		field = MatrixMarketReal
+	}
+	if field != MatrixMarketReal && field != MatrixMarketInteger && field != MatrixMarketPattern {
+		return fmt.Errorf("不支持的Matrix Market元素类型: %s", field)
+	}
+	symmetry := opts.Symmetry
+	if symmetry == "" {
+		symmetry = MatrixMarketGeneral
+	}
+	if symmetry != MatrixMarketGeneral && symmetry != MatrixMarketSymmetric {
+		return fmt.Errorf("不支持的Matrix Market对称性: %s", symmetry)
+	}
+	if symmetry == MatrixMarketSymmetric && sm.Rows != sm.Cols {
+		return fmt.Errorf("对称矩阵的行数(%d)与列数(%d)不相等", sm.Rows, sm.Cols)
+	}
+
+	// 检查元素值与对称性，并统计需要输出的元素数量
+	entries := 0
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			if field == MatrixMarketInteger && float64(data[k]) != math.Trunc(float64(data[k])) {
+				return fmt.Errorf("元素(%d, %d)的值 %v 不是整数", i, col, data[k])
+			}
+			if symmetry == MatrixMarketSymmetric {
+				if sm.At(col, i) != sm.At(i, col) {
+					return fmt.Errorf("矩阵不对称，无法以symmetric格式写入")
+				}
+				if col > i {
+					continue
+				}
+			}
+			entries++
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%%%%MatrixMarket matrix coordinate %s %s\n", field, symmetry)
+	if opts.Comment != "" {
+		for _, line := range strings.Split(opts.Comment, "\n") {
+			fmt.Fprintf(bw, "%%%s\n", line)
+		}
+	}
+	fmt.Fprintf(bw, "%d %d %d\n", sm.Rows, sm.Cols, entries)
+
+	var buf []byte
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			if symmetry == MatrixMarketSymmetric && col > i {
+				continue
+			}
+			buf = strconv.AppendInt(buf[:0], int64(i+1), 10)
+			buf = append(buf, ' ')
+			buf = strconv.AppendInt(buf, int64(col+1), 10)
+			switch field {
+			case MatrixMarketReal:
+				buf = append(buf, ' ')
+				buf = strconv.AppendFloat(buf, float64(data[k]), 'g', -1, floatBits[T]())
+			case MatrixMarketInteger:
+				buf = append(buf, ' ')
+				buf = strconv.AppendInt(buf, int64(data[k]), 10)
+			}
+			buf = append(buf, '\n')
+			if _, err := bw.Write(buf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// SaveMatrixMarket 将稀疏矩阵保存为Matrix Market文件（通常以 .mtx 为扩展名）
+func (sm *SparseMatrixOf[T]) SaveMatrixMarket(filename string, opts MatrixMarketOptions) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("无法创建文件: %v", err)
+	}
+
+	if err := sm.WriteMatrixMarket(file, opts); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
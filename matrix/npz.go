@@ -0,0 +1,579 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// npyMagic 是 .npy 文件的魔数
+var npyMagic = []byte("\x93NUMPY")
+
+var (
+	npyDescrRe   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranRe = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapeRe   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// npyArray 表示从 .npy 文件中解析出的数组
+type npyArray struct {
+	order byte   // 字节序：'<' 小端，'>' 大端，'|' 无关
+	kind  byte   // 类型：'f' 浮点，'i' 有符号整数，'u' 无符号整数，'b' 布尔，'S' 字节串，'U' Unicode字符串
+	size  int    // 每个元素的字节数（'U' 类型为字符数）
+	shape []int  // 数组形状
+	data  []byte // 原始数据
+}
+
+// npzEntry 表示写入npz文件的一个数组
+type npzEntry struct {
+	name  string // 数组名，对应zip中的 name.npy
+	descr string // numpy类型描述，例如 '<f8'
+	shape []int  // 数组形状，nil表示标量
+	data  []byte // 编码后的数据
+}
+
+// ReadNPZ 读取 scipy.sparse.save_npz 保存的 .npz 数据
+// 支持 csr/csc/coo 三种格式，以及 float/int/uint/bool 类型的元素值，结果统一转换为CSR格式；
+// 存在可选的 feature_names 字符串数组时读取为特征名
+func ReadNPZ(r io.ReaderAt, size int64) (*SparseMatrix, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("无法读取npz数据: %v", err)
+	}
+
+	arrays := make(map[string]*npyArray)
+	for _, f := range zr.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("无法打开数组 %s: %v", name, err)
+		}
+		arr, err := readNPY(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("无法解析数组 %s: %v", name, err)
+		}
+		arrays[name] = arr
+	}
+
+	get := func(name string) (*npyArray, error) {
+		arr, ok := arrays[name]
+		if !ok {
+			return nil, fmt.Errorf("npz数据中缺少数组 %s", name)
+		}
+		return arr, nil
+	}
+
+	// 解析格式与形状
+	arr, err := get("format")
+	if err != nil {
+		return nil, err
+	}
+	format, err := arr.string()
+	if err != nil {
+		return nil, err
+	}
+	if arr, err = get("shape"); err != nil {
+		return nil, err
+	}
+	shape, err := arr.ints()
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 2 || shape[0] < 0 || shape[1] < 0 {
+		return nil, fmt.Errorf("无效的矩阵形状: %v", shape)
+	}
+	rows, cols := shape[0], shape[1]
+
+	if arr, err = get("data"); err != nil {
+		return nil, err
+	}
+	data, err := arr.float64s()
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取两个索引数组
+	readIndex := func(first, second string) ([]int, []int, error) {
+		a, err := get(first)
+		if err != nil {
+			return nil, nil, err
+		}
+		x, err := a.ints()
+		if err != nil {
+			return nil, nil, err
+		}
+		if a, err = get(second); err != nil {
+			return nil, nil, err
+		}
+		y, err := a.ints()
+		if err != nil {
+			return nil, nil, err
+		}
+		return x, y, nil
+	}
+
+	var sm *SparseMatrix
+	switch format {
+	case "csr", "csc":
+		indices, indptr, err := readIndex("indices", "indptr")
+		if err != nil {
+			return nil, err
+		}
+		major, minor := rows, cols
+		if format == "csc" {
+			major, minor = cols, rows
+		}
+		if len(indptr) != major+1 || len(indices) != len(data) || indptr[0] != 0 || indptr[major] != len(data) {
+			return nil, fmt.Errorf("%s数组的长度不一致", format)
+		}
+
+		coo := NewCOOMatrix(rows, cols)
+		for i := 0; i < major; i++ {
+			if indptr[i] > indptr[i+1] {
+				return nil, fmt.Errorf("%s的indptr不是单调递增的", format)
+			}
+			for k := indptr[i]; k < indptr[i+1]; k++ {
+				if indices[k] < 0 || indices[k] >= minor {
+					return nil, fmt.Errorf("索引 %d 超出范围 %d", indices[k], minor)
+				}
+				if format == "csr" {
+					coo.Append(i, indices[k], data[k])
+				} else {
+					coo.Append(indices[k], i, data[k])
+				}
+			}
+		}
+		sm = coo.ToCSR()
+	case "coo":
+		rowIdx, colIdx, err := readIndex("row", "col")
+		if err != nil {
+			return nil, err
+		}
+		if len(rowIdx) != len(data) || len(colIdx) != len(data) {
+			return nil, fmt.Errorf("coo数组的长度不一致")
+		}
+		for k := range data {
+			if rowIdx[k] < 0 || rowIdx[k] >= rows || colIdx[k] < 0 || colIdx[k] >= cols {
+				return nil, fmt.Errorf("元素(%d, %d)超出矩阵范围(%d, %d)", rowIdx[k], colIdx[k], rows, cols)
+			}
+		}
+		coo := &COOMatrix{Rows: rows, Cols: cols, Data: data, RowIdx: rowIdx, ColIdx: colIdx}
+		sm = coo.ToCSR()
+	default:
+		return nil, fmt.Errorf("不支持的稀疏矩阵格式: %s", format)
+	}
+
+	if arr, ok := arrays["feature_names"]; ok {
+		names, err := arr.strings()
+		if err != nil {
+			return nil, err
+		}
+		if err := sm.SetFeatureNames(names); err != nil {
+			return nil, err
+		}
+	}
+
+	return sm, nil
+}
+
+// LoadNPZ 从文件中读取 scipy.sparse.save_npz 保存的稀疏矩阵
+func LoadNPZ(filename string) (*SparseMatrix, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开文件: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("无法读取文件信息: %v", err)
+	}
+	return ReadNPZ(file, info.Size())
+}
+
+// WriteNPZ 将稀疏矩阵以 scipy.sparse.save_npz 的csr格式写入 w，可直接用 scipy.sparse.load_npz 读取
+// compressed 对应 save_npz 的 compressed 参数，为 true 时使用deflate压缩；
+// SparseMatrix32 的元素值以 float32('<f4') 写入。带有特征名时额外写入 feature_names 字符串数组，
+// load_npz 会忽略该数组，在Python中可通过 numpy.load(filename)["feature_names"] 读取
+func (sm *SparseMatrixOf[T]) WriteNPZ(w io.Writer, compressed bool) error {
+	return sm.WriteNPZFormat(w, "csr", compressed)
+}
+
+// WriteNPZFormat 与 WriteNPZ 相同，但以 format 指定的格式写入，format 可以是 "csr"、"csc" 或 "coo"，
+// 分别对应 load_npz 读取后得到的 csr_matrix、csc_matrix 和 coo_matrix；csc 和 coo 格式通过 ToCSC、ToCOO 转换
+func (sm *SparseMatrixOf[T]) WriteNPZFormat(w io.Writer, format string, compressed bool) error {
+	// 与scipy一致，索引能用int32表示时使用int32
+	indexSize := 4
+	if len(sm.Data) > math.MaxInt32 || max(sm.Rows, sm.Cols) > math.MaxInt32 {
+		indexSize = 8
+	}
+	indexDescr := npyIntDescr(indexSize)
+
+	// 按 save_npz 的顺序写入各个数组：先写索引数组，再写 format、shape 和 data
+	var arrays []npzEntry
+	var data []T
+	switch format {
+	case "csr":
+		indptr := sm.RowPtr
+		if len(indptr) == 0 {
+			indptr = make([]int, sm.Rows+1)
+		}
+		arrays = []npzEntry{
+			{"indices", indexDescr, []int{len(sm.ColIdx)}, encodeInts(sm.ColIdx, indexSize)},
+			{"indptr", indexDescr, []int{len(indptr)}, encodeInts(indptr, indexSize)},
+		}
+		data = sm.Data
+	case "csc":
+		csc := sm.ToCSC()
+		arrays = []npzEntry{
+			{"indices", indexDescr, []int{len(csc.RowIdx)}, encodeInts(csc.RowIdx, indexSize)},
+			{"indptr", indexDescr, []int{len(csc.ColPtr)}, encodeInts(csc.ColPtr, indexSize)},
+		}
+		data = csc.Data
+	case "coo":
+		coo := sm.ToCOO()
+		arrays = []npzEntry{
+			{"row", indexDescr, []int{len(coo.RowIdx)}, encodeInts(coo.RowIdx, indexSize)},
+			{"col", indexDescr, []int{len(coo.ColIdx)}, encodeInts(coo.ColIdx, indexSize)},
+		}
+		data = coo.Data
+	default:
+		return fmt.Errorf("不支持的稀疏矩阵格式: %s", format)
+	}
+
+	arrays = append(arrays,
+		npzEntry{"format", "|S3", nil, []byte(format)},
+		npzEntry{"shape", "<i8", []int{2}, encodeInts([]int{sm.Rows, sm.Cols}, 8)},
+		npzEntry{"data", "<f" + strconv.Itoa(floatBits[T]()/8), []int{len(data)}, encodeFloats(data)},
+	)
+	if sm.FeatureNames != nil {
+		descr, names := encodeStrings(sm.FeatureNames)
+		arrays = append(arrays, npzEntry{"feature_names", descr, []int{len(sm.FeatureNames)}, names})
+	}
+
+	method := zip.Store
+	if compressed {
+		method = zip.Deflate
+	}
+	zw := zip.NewWriter(w)
+	for _, arr := range arrays {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: arr.name + ".npy", Method: method})
+		if err != nil {
+			return fmt.Errorf("无法写入数组 %s: %v", arr.name, err)
+		}
+		if err := writeNPY(fw, arr.descr, arr.shape, arr.data); err != nil {
+			return fmt.Errorf("无法写入数组 %s: %v", arr.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// SaveNPZ 将稀疏矩阵以csr格式保存为 .npz 文件
+func (sm *SparseMatrixOf[T]) SaveNPZ(filename string, compressed bool) error {
+	return sm.SaveNPZFormat(filename, "csr", compressed)
+}
+
+// SaveNPZFormat 将稀疏矩阵以 format 指定的格式保存为 .npz 文件，format 的取值见 WriteNPZFormat
+func (sm *SparseMatrixOf[T]) SaveNPZFormat(filename, format string, compressed bool) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("无法创建文件: %v", err)
+	}
+
+	if err := sm.WriteNPZFormat(file, format, compressed); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readNPY 解析 .npy 格式的数组，只支持C顺序或一维数组
+func readNPY(r io.Reader) (*npyArray, error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(prefix[:len(npyMagic)], npyMagic) {
+		return nil, fmt.Errorf("不是有效的npy数据")
+	}
+
+	// 1.x 版本的头部长度为2字节，2.x 及以上为4字节
+	var headerLen int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		headerLen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		headerLen = int(n)
+	default:
+		return nil, fmt.Errorf("不支持的npy版本: %d", major)
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	// 解析头部的Python字典字面量
+	m := npyDescrRe.FindSubmatch(header)
+	if m == nil || len(m[1]) < 3 {
+		return nil, fmt.Errorf("无效的npy头部: %s", header)
+	}
+	arr := &npyArray{order: m[1][0], kind: m[1][1]}
+	if arr.size, _ = strconv.Atoi(string(m[1][2:])); arr.size <= 0 {
+		return nil, fmt.Errorf("不支持的数据类型: %s", m[1])
+	}
+
+	if m = npyShapeRe.FindSubmatch(header); m == nil {
+		return nil, fmt.Errorf("无效的npy头部: %s", header)
+	}
+	count := 1
+	for _, s := range strings.Split(string(m[1]), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(s, "L"))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("无效的数组形状: %s", m[1])
+		}
+		arr.shape = append(arr.shape, n)
+		count *= n
+	}
+	if m = npyFortranRe.FindSubmatch(header); m != nil && string(m[1]) == "True" && len(arr.shape) > 1 {
+		return nil, fmt.Errorf("不支持Fortran顺序的多维数组")
+	}
+
+	itemSize := arr.size
+	if arr.kind == 'U' {
+		itemSize *= 4 // UTF-32编码
+	}
+	arr.data = make([]byte, count*itemSize)
+	if _, err := io.ReadFull(r, arr.data); err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
+
+// byteOrder 返回数组使用的字节序
+func (a *npyArray) byteOrder() binary.ByteOrder {
+	if a.order == '>' {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// float64s 将数值数组转换为float64切片
+func (a *npyArray) float64s() ([]float64, error) {
+	bo := a.byteOrder()
+	n := len(a.data) / a.size
+	out := make([]float64, n)
+	switch {
+	case a.kind == 'f' && a.size == 8:
+		for k := range out {
+			out[k] = math.Float64frombits(bo.Uint64(a.data[k*8:]))
+		}
+	case a.kind == 'f' && a.size == 4:
+		for k := range out {
+			out[k] = float64(math.Float32frombits(bo.Uint32(a.data[k*4:])))
+		}
+	case a.kind == 'i' || a.kind == 'u' || a.kind == 'b':
+		for k := range out {
+			v, err := a.intAt(k)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = float64(v)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的数值类型: %c%d", a.kind, a.size)
+	}
+	return out, nil
+}
+
+// ints 将整数数组转换为int切片
+func (a *npyArray) ints() ([]int, error) {
+	if a.kind != 'i' && a.kind != 'u' {
+		return nil, fmt.Errorf("索引数组的类型应为整数，得到: %c%d", a.kind, a.size)
+	}
+	out := make([]int, len(a.data)/a.size)
+	for k := range out {
+		v, err := a.intAt(k)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = int(v)
+	}
+	return out, nil
+}
+
+// intAt 返回整数或布尔数组的第k个元素
+func (a *npyArray) intAt(k int) (int64, error) {
+	bo := a.byteOrder()
+	b := a.data[k*a.size:]
+	signed := a.kind == 'i'
+	switch a.size {
+	case 1:
+		if signed {
+			return int64(int8(b[0])), nil
+		}
+		return int64(b[0]), nil
+	case 2:
+		if signed {
+			return int64(int16(bo.Uint16(b))), nil
+		}
+		return int64(bo.Uint16(b)), nil
+	case 4:
+		if signed {
+			return int64(int32(bo.Uint32(b))), nil
+		}
+		return int64(bo.Uint32(b)), nil
+	case 8:
+		v := bo.Uint64(b)
+		if !signed && v > math.MaxInt64 {
+			return 0, fmt.Errorf("整数 %d 超出范围", v)
+		}
+		return int64(v), nil
+	}
+	return 0, fmt.Errorf("不支持的整数类型: %c%d", a.kind, a.size)
+}
+
+// string 将字节串或Unicode字符串标量转换为string
+func (a *npyArray) string() (string, error) {
+	return a.decodeString(a.data)
+}
+
+// strings 将字节串或Unicode字符串数组转换为string切片
+func (a *npyArray) strings() ([]string, error) {
+	itemSize := a.size
+	if a.kind == 'U' {
+		itemSize *= 4
+	}
+	out := make([]string, len(a.data)/itemSize)
+	for k := range out {
+		s, err := a.decodeString(a.data[k*itemSize : (k+1)*itemSize])
+		if err != nil {
+			return nil, err
+		}
+		out[k] = s
+	}
+	return out, nil
+}
+
+// decodeString 解码单个字符串元素，末尾用于填充的0会被去掉
+func (a *npyArray) decodeString(b []byte) (string, error) {
+	switch a.kind {
+	case 'S':
+		return string(bytes.TrimRight(b, "\x00")), nil
+	case 'U':
+		bo := a.byteOrder()
+		var sb strings.Builder
+		for k := 0; k+4 <= len(b); k += 4 {
+			r := rune(bo.Uint32(b[k:]))
+			if r == 0 {
+				break
+			}
+			if !utf8.ValidRune(r) {
+				return "", fmt.Errorf("无效的Unicode字符: %d", r)
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String(), nil
+	}
+	return "", fmt.Errorf("字符串数组的类型应为S或U，得到: %c%d", a.kind, a.size)
+}
+
+// writeNPY 以 .npy 1.0 格式写入数组，头部按64字节对齐
+func writeNPY(w io.Writer, descr string, shape []int, data []byte) error {
+	dims := make([]string, len(shape))
+	for k, n := range shape {
+		dims[k] = strconv.Itoa(n)
+	}
+	shapeStr := "(" + strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shapeStr += ","
+	}
+	shapeStr += ")"
+
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': %s, }", descr, shapeStr)
+	// 魔数(6) + 版本(2) + 头部长度(2) + 头部 + 换行符，总长度为64的倍数
+	total := len(npyMagic) + 4 + len(header) + 1
+	header += strings.Repeat(" ", (64-total%64)%64) + "\n"
+
+	buf := make([]byte, 0, len(npyMagic)+4+len(header))
+	buf = append(buf, npyMagic...)
+	buf = append(buf, 1, 0)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(header)))
+	buf = append(buf, header...)
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// npyIntDescr 返回指定字节数的小端有符号整数类型描述
+func npyIntDescr(size int) string {
+	return "<i" + strconv.Itoa(size)
+}
+
+// encodeInts 将整数切片编码为小端字节序，size 为每个元素的字节数(4或8)
+func encodeInts(values []int, size int) []byte {
+	out := make([]byte, len(values)*size)
+	for k, v := range values {
+		if size == 4 {
+			binary.LittleEndian.PutUint32(out[k*4:], uint32(int32(v)))
+		} else {
+			binary.LittleEndian.PutUint64(out[k*8:], uint64(v))
+		}
+	}
+	return out
+}
+
+// encodeStrings 将字符串切片编码为numpy的定长Unicode数组('<U{n}')，返回类型描述和数据
+// 与 numpy.array(list_of_str) 相同，n 为最长字符串的字符数
+func encodeStrings(values []string) (string, []byte) {
+	n := 1
+	for _, v := range values {
+		n = max(n, utf8.RuneCountInString(v))
+	}
+	out := make([]byte, len(values)*n*4)
+	for k, v := range values {
+		pos := k * n * 4
+		for _, r := range v {
+			binary.LittleEndian.PutUint32(out[pos:], uint32(r))
+			pos += 4
+		}
+	}
+	return "<U" + strconv.Itoa(n), out
+}
+
+// encodeFloats 将浮点数切片按元素类型编码为小端字节序
+func encodeFloats[T Float](values []T) []byte {
+	size := floatBits[T]() / 8
+	out := make([]byte, len(values)*size)
+	for k, v := range values {
+		if size == 4 {
+			binary.LittleEndian.PutUint32(out[k*4:], math.Float32bits(float32(v)))
+		} else {
+			binary.LittleEndian.PutUint64(out[k*8:], math.Float64bits(float64(v)))
+		}
+	}
+	return out
+}
@@ -0,0 +1,266 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestNPZ_Fixtures 读取 testdata 中由 scipy.sparse.save_npz 生成的文件
+// 测试数据由 testdata/npz_fixtures.py 生成
+func TestNPZ_Fixtures(t *testing.T) {
+	mixed := [][]float64{
+		{0.5, 0, -1.25, 0},
+		{0, 0, 0, 3e-08},
+		{0, 7, 0, 1},
+	}
+	tests := []struct {
+		file  string
+		dense [][]float64
+	}{
+		{file: "scipy_csr.npz", dense: mixed},
+		{file: "scipy_csc.npz", dense: mixed},
+		{
+			file: "scipy_coo.npz",
+			dense: [][]float64{
+				{0, 1.5, 0},
+				{2, 0, 0},
+				{0, 0, -3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			sm, err := LoadNPZ(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatalf("LoadNPZ() error = %v", err)
+			}
+			if want := DenseToSparse(tt.dense); !reflect.DeepEqual(sm, want) {
+				t.Errorf("LoadNPZ() = %+v, want %+v", sm, want)
+			}
+		})
+	}
+}
+
+func TestNPZ_SaveLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		sm         *SparseMatrix
+		compressed bool
+	}{
+		{
+			name:       "压缩",
+			sm:         DenseToSparse([][]float64{{0.1, 0, 0}, {0, 0, 1e-300}}),
+			compressed: true,
+		},
+		{
+			name: "不压缩",
+			sm:   DenseToSparse([][]float64{{0, 2, 0, 3}, {0, 0, 0, 0}, {-1, 0, 0, 0}}),
+		},
+		{
+			name: "空矩阵",
+			sm:   &SparseMatrix{Rows: 2, Cols: 3, Data: []float64{}, RowPtr: []int{0, 0, 0}, ColIdx: []int{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpfile, err := os.CreateTemp("", "npz_test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tmpfile.Close()
+			defer os.Remove(tmpfile.Name())
+
+			if err := tt.sm.SaveNPZ(tmpfile.Name(), tt.compressed); err != nil {
+				t.Fatalf("SaveNPZ() error = %v", err)
+			}
+			loaded, err := LoadNPZ(tmpfile.Name())
+			if err != nil {
+				t.Fatalf("LoadNPZ() error = %v", err)
+			}
+			if !reflect.DeepEqual(loaded, tt.sm) {
+				t.Errorf("LoadNPZ() = %+v, want %+v", loaded, tt.sm)
+			}
+		})
+	}
+}
+
+// TestNPZ_WriteLayout 检查写出的数组名称、顺序和类型与 save_npz 一致
+func TestNPZ_WriteLayout(t *testing.T) {
+	sm := DenseToSparse([][]float64{{1, 0}, {0, 2}})
+	var buf bytes.Buffer
+	if err := sm.WriteNPZ(&buf, false); err != nil {
+		t.Fatalf("WriteNPZ() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDescr := map[string]string{
+		"indices.npy": "<i4",
+		"indptr.npy":  "<i4",
+		"format.npy":  "|S3",
+		"shape.npy":   "<i8",
+		"data.npy":    "<f8",
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 头部按64字节对齐，并以换行符结尾
+		headerLen := int(raw[8]) | int(raw[9])<<8
+		if (10+headerLen)%64 != 0 || raw[10+headerLen-1] != '\n' {
+			t.Errorf("%s 的头部没有按64字节对齐: %q", f.Name, raw[10:10+headerLen])
+		}
+		arr, err := readNPY(bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("readNPY(%s) error = %v", f.Name, err)
+		}
+		if descr := fmt.Sprintf("%c%c%d", arr.order, arr.kind, arr.size); descr != wantDescr[f.Name] {
+			t.Errorf("%s 的类型 = %s, want %s", f.Name, descr, wantDescr[f.Name])
+		}
+	}
+
+	wantNames := []string{"indices.npy", "indptr.npy", "format.npy", "shape.npy", "data.npy"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("数组顺序 = %v, want %v", names, wantNames)
+	}
+}
+
+// TestNPZ_WriteFormats 检查 WriteNPZFormat 支持的格式：csr、csc 和 coo 都能读回原矩阵，其他格式返回错误
+func TestNPZ_WriteFormats(t *testing.T) {
+	sm := DenseToSparse([][]float64{{0, 2, 0, 3}, {0, 0, 0, 0}, {-1, 0, 4, 0}})
+	sm.FeatureNames = []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		format    string
+		wantNames []string
+	}{
+		{"csr", []string{"indices.npy", "indptr.npy", "format.npy", "shape.npy", "data.npy", "feature_names.npy"}},
+		{"csc", []string{"indices.npy", "indptr.npy", "format.npy", "shape.npy", "data.npy", "feature_names.npy"}},
+		{"coo", []string{"row.npy", "col.npy", "format.npy", "shape.npy", "data.npy", "feature_names.npy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := sm.WriteNPZFormat(&buf, tt.format, true); err != nil {
+				t.Fatalf("WriteNPZFormat() error = %v", err)
+			}
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, f := range zr.File {
+				names = append(names, f.Name)
+				if f.Name != "format.npy" {
+					continue
+				}
+				rc, err := f.Open()
+				if err != nil {
+					t.Fatal(err)
+				}
+				arr, err := readNPY(rc)
+				rc.Close()
+				if err != nil {
+					t.Fatalf("readNPY(%s) error = %v", f.Name, err)
+				}
+				if format, _ := arr.string(); format != tt.format {
+					t.Errorf("format = %q, want %q", format, tt.format)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("数组顺序 = %v, want %v", names, tt.wantNames)
+			}
+
+			got, err := ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("ReadNPZ() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, sm) {
+				t.Errorf("ReadNPZ() = %+v, want %+v", got, sm)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := sm.WriteNPZFormat(&buf, "bsr", false); err == nil {
+		t.Error("WriteNPZFormat() 不支持的格式应返回错误")
+	}
+}
+
+func TestNPZ_Errors(t *testing.T) {
+	// buildNPZ 构造一个只包含指定数组的npz数据
+	buildNPZ := func(format string, shape []int, extra map[string][]int) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		write := func(name, descr string, shape []int, data []byte) {
+			fw, err := zw.Create(name + ".npy")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := writeNPY(fw, descr, shape, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		write("format", "|S3", nil, []byte(format))
+		write("shape", "<i8", []int{len(shape)}, encodeInts(shape, 8))
+		write("data", "<f8", []int{1}, encodeFloats([]float64{1}))
+		for name, values := range extra {
+			write(name, "<i8", []int{len(values)}, encodeInts(values, 8))
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	inputs := map[string][]byte{
+		"不支持的bsr格式":  buildNPZ("bsr", []int{2, 2}, map[string][]int{"indices": {0}, "indptr": {0, 1}}),
+		"缺少indptr":   buildNPZ("csr", []int{2, 2}, map[string][]int{"indices": {0}}),
+		"indptr长度错误": buildNPZ("csr", []int{2, 2}, map[string][]int{"indices": {0}, "indptr": {0, 1}}),
+		"列索引越界":      buildNPZ("csr", []int{1, 2}, map[string][]int{"indices": {2}, "indptr": {0, 1}}),
+		"coo行索引越界":   buildNPZ("coo", []int{1, 2}, map[string][]int{"row": {1}, "col": {0}}),
+		"形状不是二维":     buildNPZ("coo", []int{2}, map[string][]int{"row": {0}, "col": {0}}),
+		"不是zip数据":    []byte("not a zip file"),
+	}
+	for name, input := range inputs {
+		if _, err := ReadNPZ(bytes.NewReader(input), int64(len(input))); err == nil {
+			t.Errorf("ReadNPZ() %s 时应返回错误", name)
+		}
+	}
+}
+
+func TestNPZ_SparseMatrix32(t *testing.T) {
+	sm := DenseToSparse([][]float32{{0.1, 0}, {0, 2}})
+	var buf bytes.Buffer
+	if err := sm.WriteNPZ(&buf, true); err != nil {
+		t.Fatalf("WriteNPZ() error = %v", err)
+	}
+
+	got, err := ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("ReadNPZ() error = %v", err)
+	}
+	if want := sm.ToFloat64(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadNPZ() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package matrix
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SimilarityMetric 表示两行之间相似度的计算方式
+type SimilarityMetric int
+
+const (
+	SimilarityDot    SimilarityMetric = iota // 点积
+	SimilarityCosine                         // 余弦相似度，范数为0的行与任何行的相似度都为0
+)
+
+// TopKOptions 计算最相似的前k行时的选项
+type TopKOptions struct {
+	K           int              // 每行最多保留的结果数量，必须为正数
+	Metric      SimilarityMetric // 相似度的计算方式
+	MinScore    float64          // 大于0时，只保留相似度不低于 MinScore 的结果
+	ExcludeSelf bool             // 是否跳过下标相同的行，用于矩阵与自身比较
+	Workers     int              // 协程数量，小于等于0时使用 runtime.GOMAXPROCS(0)
+}
+
+// Neighbor 表示一个相似行及其相似度
+type Neighbor struct {
+	Index int     // 在被比较矩阵中的行号
+	Score float64 // 相似度
+}
+
+// TopKSimilar 对当前矩阵的每一行，在 other 的所有行中找出相似度最高的前k行
+// 结果的第i个元素为第i行的近邻，按相似度从高到低排列，相似度相同时按行号升序；
+// 只有与第i行至少共享一个非零特征的行才会出现在结果中。
+//
+// 计算时将 other 转换为CSC格式，每行只累加共享特征的乘积，并用大小为k的小顶堆筛选结果，
+// 每个协程只需要 O(other.Rows) 的累加空间，不会生成 n×m 的稠密结果。
+// 要求两个矩阵的列数相同，且没有重复的(行, 列)元素
+func (sm *SparseMatrixOf[T]) TopKSimilar(other *SparseMatrixOf[T], opts TopKOptions) ([][]Neighbor, error) {
+	if sm.Cols != other.Cols {
+		return nil, fmt.Errorf("列数不匹配: %d != %d", sm.Cols, other.Cols)
+	}
+	if opts.K <= 0 {
+		return nil, fmt.Errorf("K 必须为正数，得到 %d", opts.K)
+	}
+	if opts.Metric != SimilarityDot && opts.Metric != SimilarityCosine {
+		return nil, fmt.Errorf("不支持的相似度: %d", opts.Metric)
+	}
+
+	csc := other.ToCSC()
+	var normA, normB []float64
+	if opts.Metric == SimilarityCosine {
+		normA = sm.rowNorms()
+		normB = other.rowNorms()
+	}
+
+	result := make([][]Neighbor, sm.Rows)
+	sm.parallelRows(opts.Workers, func(start, end int) {
+		acc := make([]float64, other.Rows)
+		touched := make([]bool, other.Rows)
+		var candidates []int
+		h := make(neighborHeap, 0, opts.K)
+
+		for i := start; i < end; i++ {
+			// 累加与第i行共享特征的所有行的点积
+			cols, data := sm.Row(i)
+			for k, col := range cols {
+				v := float64(data[k])
+				rows, values := csc.Col(col)
+				for t, j := range rows {
+					if !touched[j] {
+						touched[j] = true
+						candidates = append(candidates, j)
+					}
+					acc[j] += v * float64(values[t])
+				}
+			}
+
+			h = h[:0]
+			for _, j := range candidates {
+				score := acc[j]
+				acc[j] = 0
+				touched[j] = false
+
+				if opts.ExcludeSelf && j == i {
+					continue
+				}
+				if opts.Metric == SimilarityCosine {
+					if normA[i] == 0 || normB[j] == 0 {
+						continue
+					}
+					score /= normA[i] * normB[j]
+				}
+				if opts.MinScore > 0 && score < opts.MinScore {
+					continue
+				}
+
+				n := Neighbor{Index: j, Score: score}
+				if len(h) < opts.K {
+					heap.Push(&h, n)
+				} else if worse(h[0], n) {
+					h[0] = n
+					heap.Fix(&h, 0)
+				}
+			}
+			candidates = candidates[:0]
+
+			neighbors := make([]Neighbor, len(h))
+			copy(neighbors, h)
+			sort.Slice(neighbors, func(a, b int) bool {
+				return worse(neighbors[b], neighbors[a])
+			})
+			result[i] = neighbors
+		}
+	})
+
+	return result, nil
+}
+
+// NeighborsToSparse 将 TopKSimilar 的结果转换为稀疏相似度矩阵
+// 结果的第(i, j)个元素为第i行与 other 的第j行的相似度，cols 为 other 的行数
+func NeighborsToSparse(neighbors [][]Neighbor, cols int) (*SparseMatrix, error) {
+	coo := NewCOOMatrix(len(neighbors), cols)
+	for i, row := range neighbors {
+		for _, n := range row {
+			if n.Index < 0 || n.Index >= cols {
+				return nil, fmt.Errorf("第%d行: 近邻行号 %d 超出范围 %d", i, n.Index, cols)
+			}
+			coo.Append(i, n.Index, n.Score)
+		}
+	}
+	return coo.ToCSR(), nil
+}
+
+// rowNorms 返回每一行的L2范数
+func (sm *SparseMatrixOf[T]) rowNorms() []float64 {
+	norms := make([]float64, sm.Rows)
+	for i := range norms {
+		_, data := sm.Row(i)
+		sum := 0.0
+		for _, v := range data {
+			sum += float64(v) * float64(v)
+		}
+		norms[i] = math.Sqrt(sum)
+	}
+	return norms
+}
+
+// worse 判断 a 是否排在 b 之后：相似度更低，或相似度相同但行号更大
+func worse(a, b Neighbor) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.Index > b.Index
+}
+
+// neighborHeap 是按 worse 排序的小顶堆，堆顶为当前最差的结果
+type neighborHeap []Neighbor
+
+func (h neighborHeap) Len() int            { return len(h) }
+func (h neighborHeap) Less(i, j int) bool  { return worse(h[i], h[j]) }
+func (h neighborHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x interface{}) { *h = append(*h, x.(Neighbor)) }
+func (h *neighborHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
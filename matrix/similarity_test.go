@@ -0,0 +1,141 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// bruteForceTopK 在稠密矩阵上逐对计算相似度，作为 TopKSimilar 的期望结果
+func bruteForceTopK(a, b [][]float64, opts TopKOptions) [][]Neighbor {
+	norm := func(x []float64) float64 {
+		s := 0.0
+		for _, v := range x {
+			s += v * v
+		}
+		return math.Sqrt(s)
+	}
+
+	result := make([][]Neighbor, len(a))
+	for i := range a {
+		var neighbors []Neighbor
+		for j := range b {
+			if opts.ExcludeSelf && i == j {
+				continue
+			}
+			shared := false
+			score := 0.0
+			for k := range a[i] {
+				if a[i][k] != 0 && b[j][k] != 0 {
+					shared = true
+					score += a[i][k] * b[j][k]
+				}
+			}
+			if !shared {
+				continue
+			}
+			if opts.Metric == SimilarityCosine {
+				score /= norm(a[i]) * norm(b[j])
+			}
+			if opts.MinScore > 0 && score < opts.MinScore {
+				continue
+			}
+			neighbors = append(neighbors, Neighbor{Index: j, Score: score})
+		}
+		sort.Slice(neighbors, func(x, y int) bool {
+			if neighbors[x].Score != neighbors[y].Score {
+				return neighbors[x].Score > neighbors[y].Score
+			}
+			return neighbors[x].Index < neighbors[y].Index
+		})
+		if len(neighbors) > opts.K {
+			neighbors = neighbors[:opts.K]
+		}
+		result[i] = append([]Neighbor{}, neighbors...)
+	}
+	return result
+}
+
+func TestSparseMatrix_TopKSimilar(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomDense := func(rows, cols int) [][]float64 {
+		dense := make([][]float64, rows)
+		for i := range dense {
+			dense[i] = make([]float64, cols)
+			for j := range dense[i] {
+				// 取整数值，避免浮点累加顺序不同带来的误差影响排序
+				if rng.Float64() < 0.3 {
+					dense[i][j] = float64(rng.Intn(5) + 1)
+				}
+			}
+		}
+		return dense
+	}
+	a := randomDense(30, 12)
+	b := randomDense(25, 12)
+
+	tests := []struct {
+		name string
+		b    [][]float64
+		opts TopKOptions
+	}{
+		{name: "点积", b: b, opts: TopKOptions{K: 3, Metric: SimilarityDot}},
+		{name: "余弦", b: b, opts: TopKOptions{K: 5, Metric: SimilarityCosine, Workers: 4}},
+		{name: "余弦阈值", b: b, opts: TopKOptions{K: 5, Metric: SimilarityCosine, MinScore: 0.5, Workers: 3}},
+		{name: "排除自身", b: a, opts: TopKOptions{K: 2, Metric: SimilarityCosine, ExcludeSelf: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DenseToSparse(a).TopKSimilar(DenseToSparse(tt.b), tt.opts)
+			if err != nil {
+				t.Fatalf("TopKSimilar() error = %v", err)
+			}
+			want := bruteForceTopK(a, tt.b, tt.opts)
+			for i := range want {
+				if len(got[i]) != len(want[i]) {
+					t.Fatalf("第%d行: TopKSimilar() = %v, want %v", i, got[i], want[i])
+				}
+				for k := range want[i] {
+					if got[i][k].Index != want[i][k].Index || math.Abs(got[i][k].Score-want[i][k].Score) > 1e-12 {
+						t.Fatalf("第%d行: TopKSimilar() = %v, want %v", i, got[i], want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSparseMatrix_TopKSimilar_Errors(t *testing.T) {
+	sm := newTestSparse()
+	if _, err := sm.TopKSimilar(DenseToSparse([][]float64{{1, 2}}), TopKOptions{K: 1}); err == nil {
+		t.Error("TopKSimilar() 列数不匹配时应返回错误")
+	}
+	if _, err := sm.TopKSimilar(sm, TopKOptions{}); err == nil {
+		t.Error("TopKSimilar() K 不为正数时应返回错误")
+	}
+	if _, err := sm.TopKSimilar(sm, TopKOptions{K: 1, Metric: SimilarityMetric(5)}); err == nil {
+		t.Error("TopKSimilar() 不支持的相似度应返回错误")
+	}
+}
+
+func TestNeighborsToSparse(t *testing.T) {
+	neighbors := [][]Neighbor{
+		{{Index: 2, Score: 0.9}, {Index: 0, Score: 0.5}},
+		nil,
+		{{Index: 1, Score: 0.7}},
+	}
+	got, err := NeighborsToSparse(neighbors, 3)
+	if err != nil {
+		t.Fatalf("NeighborsToSparse() error = %v", err)
+	}
+	want := DenseToSparse([][]float64{{0.5, 0, 0.9}, {0, 0, 0}, {0, 0.7, 0}})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NeighborsToSparse() = %+v, want %+v", got, want)
+	}
+
+	if _, err := NeighborsToSparse(neighbors, 2); err == nil {
+		t.Error("NeighborsToSparse() 行号越界时应返回错误")
+	}
+}
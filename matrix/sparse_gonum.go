@@ -0,0 +1,68 @@
+package matrix
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// 编译期检查 SparseMatrix 和 SparseMatrix32 是否实现了 gonum 的相关接口
+var (
+	_ mat.Matrix         = (*SparseMatrix)(nil)
+	_ mat.NonZeroDoer    = (*SparseMatrix)(nil)
+	_ mat.RowNonZeroDoer = (*SparseMatrix)(nil)
+	_ mat.Matrix         = (*SparseMatrix32)(nil)
+	_ mat.NonZeroDoer    = (*SparseMatrix32)(nil)
+	_ mat.RowNonZeroDoer = (*SparseMatrix32)(nil)
+)
+
+// Dims 返回矩阵的行数和列数，实现 mat.Matrix 接口
+func (sm *SparseMatrixOf[T]) Dims() (r, c int) {
+	return sm.Rows, sm.Cols
+}
+
+// At 返回第i行第j列的元素值，实现 mat.Matrix 接口
+// Validate 允许行内列索引无序，因此逐个扫描第i行的元素，时间复杂度为O(nnz(row))；
+// 若存在重复的(行, 列)元素，返回它们的和；float32 元素会转换为 float64
+func (sm *SparseMatrixOf[T]) At(i, j int) float64 {
+	if uint(i) >= uint(sm.Rows) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(sm.Cols) {
+		panic(mat.ErrColAccess)
+	}
+
+	cols, data := sm.Row(i)
+	v := 0.0
+	for k, col := range cols {
+		if col == j {
+			v += float64(data[k])
+		}
+	}
+	return v
+}
+
+// T 返回矩阵的隐式转置，实现 mat.Matrix 接口
+// 如需显式的稀疏转置矩阵，请使用 Transpose
+func (sm *SparseMatrixOf[T]) T() mat.Matrix {
+	return mat.Transpose{Matrix: sm}
+}
+
+// DoNonZero 对矩阵中的每个非零元素调用 fn，实现 mat.NonZeroDoer 接口
+// gonum 中的部分函数（如 mat.Sum）会利用该接口跳过零元素
+func (sm *SparseMatrixOf[T]) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < sm.Rows; i++ {
+		sm.DoRowNonZero(i, fn)
+	}
+}
+
+// DoRowNonZero 对第i行的每个非零元素调用 fn，实现 mat.RowNonZeroDoer 接口
+func (sm *SparseMatrixOf[T]) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if uint(i) >= uint(sm.Rows) {
+		panic(mat.ErrRowAccess)
+	}
+	cols, data := sm.Row(i)
+	for k, col := range cols {
+		if data[k] != 0 {
+			fn(i, col, float64(data[k]))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/yinziyang/mlkit/preprocessing/standard_scaler"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSparseMatrix_At(t *testing.T) {
+	denseData := [][]float64{
+		{1, 0, 2, 0},
+		{0, 0, 0, 0},
+		{0, 3, 0, 4},
+	}
+	sm := DenseToSparse(denseData)
+
+	r, c := sm.Dims()
+	if r != 3 || c != 4 {
+		t.Fatalf("Dims() = (%d, %d), want (3, 4)", r, c)
+	}
+	for i := range denseData {
+		for j := range denseData[i] {
+			if got := sm.At(i, j); got != denseData[i][j] {
+				t.Errorf("At(%d, %d) = %v, want %v", i, j, got, denseData[i][j])
+			}
+			if got := sm.T().At(j, i); got != denseData[i][j] {
+				t.Errorf("T().At(%d, %d) = %v, want %v", j, i, got, denseData[i][j])
+			}
+		}
+	}
+
+	// 重复元素求和
+	coo := NewCOOMatrix(1, 2)
+	coo.Append(0, 1, 1.5)
+	coo.Append(0, 1, 2)
+	if got := coo.ToCSR().At(0, 1); got != 3.5 {
+		t.Errorf("At() 重复元素 = %v, want 3.5", got)
+	}
+
+	defer func() {
+		if r := recover(); r != mat.ErrColAccess {
+			t.Errorf("At() 越界时应 panic(mat.ErrColAccess), 得到 %v", r)
+		}
+	}()
+	sm.At(0, 4)
+}
+
+func TestSparseMatrix_AtUnsortedRow(t *testing.T) {
+	// Validate 允许行内列索引无序，At 的结果应与 ToGonumDense 一致
+	sm := &SparseMatrix{
+		Rows:   1,
+		Cols:   3,
+		Data:   []float64{5, 7},
+		RowPtr: []int{0, 2},
+		ColIdx: []int{2, 0},
+	}
+	if err := sm.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	dense, err := sm.ToGonumDense()
+	if err != nil {
+		t.Fatalf("ToGonumDense() error = %v", err)
+	}
+	for j, want := range []float64{7, 0, 5} {
+		if got := sm.At(0, j); got != want {
+			t.Errorf("At(0, %d) = %v, want %v", j, got, want)
+		}
+	}
+	if !mat.Equal(sm, dense) {
+		t.Errorf("At() = %v, want %v", mat.Formatted(sm), mat.Formatted(dense))
+	}
+}
+
+func TestSparseMatrix_GonumInterop(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{1, 0, 2},
+		{0, 3, 0},
+	})
+	dense, _ := sm.ToGonumDense()
+
+	if got, want := fmt.Sprintf("%v", mat.Formatted(sm)), fmt.Sprintf("%v", mat.Formatted(dense)); got != want {
+		t.Errorf("mat.Formatted() = %q, want %q", got, want)
+	}
+	if got := mat.Sum(sm); got != 6 {
+		t.Errorf("mat.Sum() = %v, want 6", got)
+	}
+
+	var prod mat.Dense
+	prod.Mul(sm, sm.T())
+	want := mat.NewDense(2, 2, []float64{5, 0, 0, 9})
+	if !mat.Equal(&prod, want) {
+		t.Errorf("Mul(A, Aᵀ) = %v, want %v", mat.Formatted(&prod), mat.Formatted(want))
+	}
+}
+
+func TestSparseMatrix_StandardScalerFit(t *testing.T) {
+	denseData := [][]float64{
+		{1, 0, 2, 0},
+		{0, 3, 0, 4},
+		{5, 0, 6, 0},
+		{0, 7, 0, 8},
+	}
+	sm := DenseToSparse(denseData)
+	dense, _ := sm.ToGonumDense()
+
+	sparseScaler := standard_scaler.NewStandardScaler(true, true)
+	if err := sparseScaler.Fit(sm); err != nil {
+		t.Fatalf("Fit(SparseMatrix) error = %v", err)
+	}
+	denseScaler := standard_scaler.NewStandardScaler(true, true)
+	if err := denseScaler.Fit(dense); err != nil {
+		t.Fatalf("Fit(Dense) error = %v", err)
+	}
+
+	for j := range denseScaler.Mean_ {
+		if math.Abs(sparseScaler.Mean_[j]-denseScaler.Mean_[j]) > 1e-12 ||
+			math.Abs(sparseScaler.Scale_[j]-denseScaler.Scale_[j]) > 1e-12 {
+			t.Errorf("第%d列统计量不一致: sparse=(%v, %v), dense=(%v, %v)", j,
+				sparseScaler.Mean_[j], sparseScaler.Scale_[j], denseScaler.Mean_[j], denseScaler.Scale_[j])
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package matrix
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// MulVec 计算稀疏矩阵与稠密向量的乘积 y = A·x
+// 只遍历非零元素，时间复杂度为O(nnz)
+func (sm *SparseMatrixOf[T]) MulVec(x mat.Vector) (*mat.VecDense, error) {
+	if err := sm.checkNonZeroDims(); err != nil {
+		return nil, err
+	}
+	if x.Len() != sm.Cols {
+		return nil, fmt.Errorf("向量长度(%d)与矩阵列数(%d)不匹配", x.Len(), sm.Cols)
+	}
+
+	xs := vectorData(x)
+	y := mat.NewVecDense(sm.Rows, nil)
+	sm.mulVecRows(xs, y.RawVector().Data, 0, sm.Rows)
+	return y, nil
+}
+
+// TMulVec 计算稀疏矩阵转置与稠密向量的乘积 y = Aᵀ·x
+// 无需显式构造转置矩阵
+func (sm *SparseMatrixOf[T]) TMulVec(x mat.Vector) (*mat.VecDense, error) {
+	if err := sm.checkNonZeroDims(); err != nil {
+		return nil, err
+	}
+	if x.Len() != sm.Rows {
+		return nil, fmt.Errorf("向量长度(%d)与矩阵行数(%d)不匹配", x.Len(), sm.Rows)
+	}
+
+	xs := vectorData(x)
+	y := mat.NewVecDense(sm.Cols, nil)
+	ys := y.RawVector().Data
+	for i := 0; i < sm.Rows; i++ {
+		if xs[i] == 0 {
+			continue
+		}
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			ys[col] += float64(data[k]) * xs[i]
+		}
+	}
+	return y, nil
+}
+
+// MulDense 计算稀疏矩阵与稠密矩阵的乘积 C = A·B
+func (sm *SparseMatrixOf[T]) MulDense(b mat.Matrix) (*mat.Dense, error) {
+	if err := sm.checkNonZeroDims(); err != nil {
+		return nil, err
+	}
+	br, bc := b.Dims()
+	if br != sm.Cols {
+		return nil, fmt.Errorf("矩阵维度不匹配: (%d, %d) × (%d, %d)", sm.Rows, sm.Cols, br, bc)
+	}
+
+	bd := denseOf(b)
+	c := mat.NewDense(sm.Rows, bc, nil)
+	sm.mulDenseRows(bd, c, 0, sm.Rows)
+	return c, nil
+}
+
+// TMulDense 计算稀疏矩阵转置与稠密矩阵的乘积 C = Aᵀ·B
+// 无需显式构造转置矩阵，常用于计算线性模型的梯度
+func (sm *SparseMatrixOf[T]) TMulDense(b mat.Matrix) (*mat.Dense, error) {
+	if err := sm.checkNonZeroDims(); err != nil {
+		return nil, err
+	}
+	br, bc := b.Dims()
+	if br != sm.Rows {
+		return nil, fmt.Errorf("矩阵维度不匹配: (%d, %d)ᵀ × (%d, %d)", sm.Rows, sm.Cols, br, bc)
+	}
+
+	bd := denseOf(b)
+	c := mat.NewDense(sm.Cols, bc, nil)
+	for i := 0; i < sm.Rows; i++ {
+		bRow := bd.RawRowView(i)
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			floats.AddScaled(c.RawRowView(col), float64(data[k]), bRow)
+		}
+	}
+	return c, nil
+}
+
+// Transpose 返回稀疏矩阵的转置，结果仍为CSR格式且行内列索引有序
+// 转置后原来的行变为列，因此结果不带特征名
+func (sm *SparseMatrixOf[T]) Transpose() *SparseMatrixOf[T] {
+	nnz := len(sm.Data)
+	t := &SparseMatrixOf[T]{
+		Rows:   sm.Cols,
+		Cols:   sm.Rows,
+		Data:   make([]T, nnz),
+		RowPtr: make([]int, sm.Cols+1),
+		ColIdx: make([]int, nnz),
+	}
+
+	// 统计每列的元素个数，得到转置矩阵的行指针
+	for _, col := range sm.ColIdx {
+		t.RowPtr[col+1]++
+	}
+	for j := 0; j < sm.Cols; j++ {
+		t.RowPtr[j+1] += t.RowPtr[j]
+	}
+
+	// 按原矩阵的行顺序填充，转置后每行的列索引自然有序
+	next := make([]int, sm.Cols)
+	copy(next, t.RowPtr[:sm.Cols])
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			pos := next[col]
+			t.ColIdx[pos] = i
+			t.Data[pos] = data[k]
+			next[col]++
+		}
+	}
+
+	return t
+}
+
+// ParallelMulVec 并发计算 y = A·x，按行分块交给多个协程处理
+// workers 为协程数量，小于等于0时使用 runtime.GOMAXPROCS(0)
+func (sm *SparseMatrixOf[T]) ParallelMulVec(x mat.Vector, workers int) (*mat.VecDense, error) {
+	if err := sm.checkNonZeroDims(); err != nil {
+		return nil, err
+	}
+	if x.Len() != sm.Cols {
+		return nil, fmt.Errorf("向量长度(%d)与矩阵列数(%d)不匹配", x.Len(), sm.Cols)
+	}
+
+	xs := vectorData(x)
+	y := mat.NewVecDense(sm.Rows, nil)
+	ys := y.RawVector().Data
+	sm.parallelRows(workers, func(start, end int) {
+		sm.mulVecRows(xs, ys, start, end)
+	})
+	return y, nil
+}
+
+// ParallelMulDense 并发计算 C = A·B，按行分块交给多个协程处理
+// workers 为协程数量，小于等于0时使用 runtime.GOMAXPROCS(0)
+func (sm *SparseMatrixOf[T]) ParallelMulDense(b mat.Matrix, workers int) (*mat.Dense, error) {
+	if err := sm.checkNonZeroDims(); err != nil {
+		return nil, err
+	}
+	br, bc := b.Dims()
+	if br != sm.Cols {
+		return nil, fmt.Errorf("矩阵维度不匹配: (%d, %d) × (%d, %d)", sm.Rows, sm.Cols, br, bc)
+	}
+
+	bd := denseOf(b)
+	c := mat.NewDense(sm.Rows, bc, nil)
+	sm.parallelRows(workers, func(start, end int) {
+		sm.mulDenseRows(bd, c, start, end)
+	})
+	return c, nil
+}
+
+// checkNonZeroDims gonum 的向量和矩阵不能有长度为0的维度，矩阵的行数或列数为0时返回错误
+func (sm *SparseMatrixOf[T]) checkNonZeroDims() error {
+	if sm.Rows == 0 || sm.Cols == 0 {
+		return fmt.Errorf("矩阵维度(%d, %d)包含0，无法构造 gonum 的向量或矩阵", sm.Rows, sm.Cols)
+	}
+	return nil
+}
+
+// mulVecRows 计算 y[start:end] = A[start:end, :]·x
+func (sm *SparseMatrixOf[T]) mulVecRows(x, y []float64, start, end int) {
+	for i := start; i < end; i++ {
+		cols, data := sm.Row(i)
+		sum := 0.0
+		for k, col := range cols {
+			sum += float64(data[k]) * x[col]
+		}
+		y[i] = sum
+	}
+}
+
+// mulDenseRows 计算 C[start:end, :] = A[start:end, :]·B
+func (sm *SparseMatrixOf[T]) mulDenseRows(b, c *mat.Dense, start, end int) {
+	for i := start; i < end; i++ {
+		cRow := c.RawRowView(i)
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			floats.AddScaled(cRow, float64(data[k]), b.RawRowView(col))
+		}
+	}
+}
+
+// parallelRows 将所有行按连续的行块分配给多个协程执行 fn
+// 每个协程只写入自己负责的行，因此无需加锁
+func (sm *SparseMatrixOf[T]) parallelRows(workers int, fn func(start, end int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > sm.Rows {
+		workers = sm.Rows
+	}
+	if workers <= 1 {
+		fn(0, sm.Rows)
+		return
+	}
+
+	chunkSize := (sm.Rows + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < sm.Rows; start += chunkSize {
+		end := start + chunkSize
+		if end > sm.Rows {
+			end = sm.Rows
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// vectorData 返回向量的连续存储数据，必要时进行复制
+func vectorData(x mat.Vector) []float64 {
+	if v, ok := x.(*mat.VecDense); ok {
+		raw := v.RawVector()
+		if raw.Inc == 1 {
+			return raw.Data[:v.Len()]
+		}
+	}
+	data := make([]float64, x.Len())
+	for i := range data {
+		data[i] = x.AtVec(i)
+	}
+	return data
+}
+
+// denseOf 将任意矩阵转换为 *mat.Dense，已是 *mat.Dense 时直接返回
+func denseOf(m mat.Matrix) *mat.Dense {
+	if d, ok := m.(*mat.Dense); ok {
+		return d
+	}
+	return mat.DenseCopyOf(m)
+}
@@ -0,0 +1,168 @@
+package matrix
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func newTestSparse() *SparseMatrix {
+	return DenseToSparse([][]float64{
+		{1, 0, 2, 0},
+		{0, 0, 0, 0},
+		{0, 3, 0, 4},
+		{5, 0, 0, 6},
+		{0, 7, 8, 0},
+	})
+}
+
+func TestSparseMatrix_MulVec(t *testing.T) {
+	sm := newTestSparse()
+	dense, _ := sm.ToGonumDense()
+	x := mat.NewVecDense(4, []float64{1, -2, 0.5, 3})
+
+	var want mat.VecDense
+	want.MulVec(dense, x)
+
+	got, err := sm.MulVec(x)
+	if err != nil {
+		t.Fatalf("MulVec() error = %v", err)
+	}
+	if !mat.EqualApprox(got, &want, 1e-12) {
+		t.Errorf("MulVec() = %v, want %v", mat.Formatted(got), mat.Formatted(&want))
+	}
+
+	par, err := sm.ParallelMulVec(x, 3)
+	if err != nil {
+		t.Fatalf("ParallelMulVec() error = %v", err)
+	}
+	if !mat.EqualApprox(par, &want, 1e-12) {
+		t.Errorf("ParallelMulVec() = %v, want %v", mat.Formatted(par), mat.Formatted(&want))
+	}
+
+	if _, err := sm.MulVec(mat.NewVecDense(3, nil)); err == nil {
+		t.Error("MulVec() 维度不匹配时应返回错误")
+	}
+}
+
+func TestSparseMatrix_TMulVec(t *testing.T) {
+	sm := newTestSparse()
+	dense, _ := sm.ToGonumDense()
+	x := mat.NewVecDense(5, []float64{1, 2, 3, 4, 5})
+
+	var want mat.VecDense
+	want.MulVec(dense.T(), x)
+
+	got, err := sm.TMulVec(x)
+	if err != nil {
+		t.Fatalf("TMulVec() error = %v", err)
+	}
+	if !mat.EqualApprox(got, &want, 1e-12) {
+		t.Errorf("TMulVec() = %v, want %v", mat.Formatted(got), mat.Formatted(&want))
+	}
+}
+
+func TestSparseMatrix_MulDense(t *testing.T) {
+	sm := newTestSparse()
+	dense, _ := sm.ToGonumDense()
+	b := mat.NewDense(4, 2, []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+		7, 8,
+	})
+
+	var want mat.Dense
+	want.Mul(dense, b)
+
+	got, err := sm.MulDense(b)
+	if err != nil {
+		t.Fatalf("MulDense() error = %v", err)
+	}
+	if !mat.EqualApprox(got, &want, 1e-12) {
+		t.Errorf("MulDense() = %v, want %v", mat.Formatted(got), mat.Formatted(&want))
+	}
+
+	for _, workers := range []int{0, 1, 2, 16} {
+		par, err := sm.ParallelMulDense(b, workers)
+		if err != nil {
+			t.Fatalf("ParallelMulDense(%d) error = %v", workers, err)
+		}
+		if !mat.EqualApprox(par, &want, 1e-12) {
+			t.Errorf("ParallelMulDense(%d) = %v, want %v", workers, mat.Formatted(par), mat.Formatted(&want))
+		}
+	}
+
+	if _, err := sm.MulDense(mat.NewDense(5, 2, nil)); err == nil {
+		t.Error("MulDense() 维度不匹配时应返回错误")
+	}
+}
+
+func TestSparseMatrix_TMulDense(t *testing.T) {
+	sm := newTestSparse()
+	dense, _ := sm.ToGonumDense()
+	b := mat.NewDense(5, 2, []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+		7, 8,
+		9, 10,
+	})
+
+	var want mat.Dense
+	want.Mul(dense.T(), b)
+
+	got, err := sm.TMulDense(b)
+	if err != nil {
+		t.Fatalf("TMulDense() error = %v", err)
+	}
+	if !mat.EqualApprox(got, &want, 1e-12) {
+		t.Errorf("TMulDense() = %v, want %v", mat.Formatted(got), mat.Formatted(&want))
+	}
+}
+
+func TestSparseMatrix_Transpose(t *testing.T) {
+	sm := newTestSparse()
+	dense, _ := sm.ToGonumDense()
+
+	tr := sm.Transpose()
+	if tr.Rows != sm.Cols || tr.Cols != sm.Rows {
+		t.Fatalf("Transpose() dims = (%d, %d), want (%d, %d)", tr.Rows, tr.Cols, sm.Cols, sm.Rows)
+	}
+	got, err := tr.ToGonumDense()
+	if err != nil {
+		t.Fatalf("ToGonumDense() error = %v", err)
+	}
+	if !mat.Equal(got, dense.T()) {
+		t.Errorf("Transpose() = %v, want %v", mat.Formatted(got), mat.Formatted(dense.T()))
+	}
+}
+
+func TestSparseMatrix_ZeroDims(t *testing.T) {
+	// 维度为0的矩阵是合法的，例如 SelectRows 得到的空结果，但 gonum 无法表示零长度的结果
+	empty := NewSparseMatrix(0, 3)
+	x := mat.NewVecDense(3, []float64{1, 2, 3})
+	b := mat.NewDense(3, 2, nil)
+
+	if _, err := empty.MulVec(x); err == nil {
+		t.Error("MulVec() 行数为0时应返回错误")
+	}
+	if _, err := empty.ParallelMulVec(x, 2); err == nil {
+		t.Error("ParallelMulVec() 行数为0时应返回错误")
+	}
+	if _, err := empty.TMulVec(&mat.VecDense{}); err == nil {
+		t.Error("TMulVec() 行数为0时应返回错误")
+	}
+	if _, err := empty.MulDense(b); err == nil {
+		t.Error("MulDense() 行数为0时应返回错误")
+	}
+	if _, err := empty.ParallelMulDense(b, 2); err == nil {
+		t.Error("ParallelMulDense() 行数为0时应返回错误")
+	}
+	if _, err := empty.TMulDense(&mat.Dense{}); err == nil {
+		t.Error("TMulDense() 行数为0时应返回错误")
+	}
+	if _, err := NewSparseMatrix(2, 0).ToGonumDense(); err == nil {
+		t.Error("ToGonumDense() 列数为0时应返回错误")
+	}
+}
@@ -3,67 +3,233 @@ package matrix
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
 	"gonum.org/v1/gonum/mat"
 )
 
-// SparseMatrix 表示稀疏矩阵的数据结构
-// 采用CSR(Compressed Sparse Row)格式存储，只存储非零元素
-type SparseMatrix struct {
-	Rows   int       // 矩阵的行数
-	Cols   int       // 矩阵的列数
-	Data   []float64 // 非零元素值
-	RowIdx []int     // 非零元素的行索引
-	ColIdx []int     // 非零元素的列索引
+// Float 是稀疏矩阵元素值允许使用的类型
+type Float interface {
+	float32 | float64
+}
+
+// floatBits 返回元素类型的位数(32或64)，用于格式化和序列化
+func floatBits[T Float]() int {
+	var v T
+	if _, ok := any(v).(float32); ok {
+		return 32
+	}
+	return 64
+}
+
+// SparseMatrixOf 表示稀疏矩阵的数据结构，元素值类型为 float32 或 float64
+// 采用CSR(Compressed Sparse Row)格式存储，只存储非零元素：
+// 第i行的非零元素为 Data[RowPtr[i]:RowPtr[i+1]]，对应的列索引为 ColIdx[RowPtr[i]:RowPtr[i+1]]，
+// 每行内的列索引按升序排列。RowPtr 为 nil 时表示没有任何非零元素的空矩阵
+type SparseMatrixOf[T Float] struct {
+	Rows   int   // 矩阵的行数
+	Cols   int   // 矩阵的列数
+	Data   []T   // 非零元素值，按行依次存放
+	RowPtr []int // 行指针，长度为Rows+1，RowPtr[i]为第i行第一个非零元素在Data中的位置
+	ColIdx []int // 非零元素的列索引
+
+	// FeatureNames 为可选的特征名，为nil或长度等于Cols，FeatureNames[j] 为第j列对应的特征；
+	// 特征名会随列的拼接、选取以及二进制/npz序列化一起保留
+	FeatureNames []string
+}
+
+// SparseMatrix 是元素值为 float64 的稀疏矩阵
+type SparseMatrix = SparseMatrixOf[float64]
+
+// SparseMatrix32 是元素值为 float32 的稀疏矩阵，内存占用约为 SparseMatrix 的一半
+type SparseMatrix32 = SparseMatrixOf[float32]
+
+// NewSparseMatrix 创建一个指定行列数、不含非零元素的稀疏矩阵
+func NewSparseMatrix(rows, cols int) *SparseMatrix {
+	return NewSparseMatrixOf[float64](rows, cols)
+}
+
+// NewSparseMatrixOf 创建一个指定元素类型和行列数、不含非零元素的稀疏矩阵
+func NewSparseMatrixOf[T Float](rows, cols int) *SparseMatrixOf[T] {
+	return &SparseMatrixOf[T]{
+		Rows:   rows,
+		Cols:   cols,
+		RowPtr: make([]int, rows+1),
+	}
 }
 
 // GetNumFeatures 返回特征维度（列数）
-func (s *SparseMatrix) GetNumFeatures() int {
+func (s *SparseMatrixOf[T]) GetNumFeatures() int {
 	return s.Cols
 }
 
+// NNZ 返回矩阵中存储的非零元素个数
+func (sm *SparseMatrixOf[T]) NNZ() int {
+	return len(sm.Data)
+}
+
+// rowBounds 返回第i行的非零元素在Data中的起止位置
+func (sm *SparseMatrixOf[T]) rowBounds(i int) (int, int) {
+	if len(sm.RowPtr) == 0 {
+		return 0, 0
+	}
+	return sm.RowPtr[i], sm.RowPtr[i+1]
+}
+
+// Row 返回第i行非零元素的列索引和值
+// 返回的切片直接引用矩阵的底层存储，时间复杂度为O(1)，修改它们会影响矩阵本身
+func (sm *SparseMatrixOf[T]) Row(i int) ([]int, []T) {
+	start, end := sm.rowBounds(i)
+	return sm.ColIdx[start:end:end], sm.Data[start:end:end]
+}
+
 // Validate 验证稀疏矩阵的有效性
-// 检查所有列索引是否在合法范围内
-func (sm *SparseMatrix) Validate() error {
+// 检查行列数非负、Data 与 ColIdx 长度相等、RowPtr 的长度和单调性，以及所有列索引都在 [0, Cols) 范围内；
+// 不要求行内列索引有序或无重复，这类矩阵可以通过 Canonicalize 规范化
+func (sm *SparseMatrixOf[T]) Validate() error {
+	if sm.Rows < 0 || sm.Cols < 0 {
+		return fmt.Errorf("无效的矩阵尺寸: (%d, %d)", sm.Rows, sm.Cols)
+	}
+	if len(sm.Data) != len(sm.ColIdx) {
+		return fmt.Errorf("Data长度(%d)与ColIdx长度(%d)不一致", len(sm.Data), len(sm.ColIdx))
+	}
+
+	// 检查行指针，RowPtr 为 nil 时只允许没有非零元素
+	if len(sm.RowPtr) == 0 {
+		if len(sm.Data) != 0 {
+			return fmt.Errorf("RowPtr为空，但存在 %d 个非零元素", len(sm.Data))
+		}
+	} else {
+		if len(sm.RowPtr) != sm.Rows+1 {
+			return fmt.Errorf("RowPtr长度(%d)应为行数加1(%d)", len(sm.RowPtr), sm.Rows+1)
+		}
+		if sm.RowPtr[0] != 0 || sm.RowPtr[sm.Rows] != len(sm.Data) {
+			return fmt.Errorf("RowPtr应从0开始并以非零元素个数(%d)结束", len(sm.Data))
+		}
+		for i := 0; i < sm.Rows; i++ {
+			if sm.RowPtr[i] > sm.RowPtr[i+1] {
+				return fmt.Errorf("第%d行的行指针不是单调递增的", i)
+			}
+		}
+	}
+
 	// 检查列索引是否超出特征维度
 	for _, col := range sm.ColIdx {
-		if col > sm.Cols {
+		if col < 0 || col >= sm.Cols {
 			return fmt.Errorf("列索引 %d 超出特征维度 %d", col, sm.Cols)
 		}
 	}
+
+	if sm.FeatureNames != nil && len(sm.FeatureNames) != sm.Cols {
+		return fmt.Errorf("特征名数量(%d)与列数(%d)不一致", len(sm.FeatureNames), sm.Cols)
+	}
+	return nil
+}
+
+// Canonicalize 将矩阵原地转换为规范形式：
+// 行内按列索引排序、合并重复的(行, 列)元素（值相加）、删除值为0的元素
+// 矩阵结构无效时返回 Validate 的错误，矩阵保持不变
+func (sm *SparseMatrixOf[T]) Canonicalize() error {
+	if err := sm.Validate(); err != nil {
+		return err
+	}
+	if len(sm.RowPtr) == 0 {
+		sm.RowPtr = make([]int, sm.Rows+1)
+		return nil
+	}
+
+	// 逐行整理后向前紧凑存放，写入位置不会超过读取位置
+	nnz := 0
+	start := 0
+	for i := 0; i < sm.Rows; i++ {
+		end := sm.RowPtr[i+1]
+		cols, data := sm.ColIdx[start:end], sm.Data[start:end]
+		sortRow(cols, data)
+
+		for k := 0; k < len(cols); {
+			col, sum := cols[k], data[k]
+			for k++; k < len(cols) && cols[k] == col; k++ {
+				sum += data[k]
+			}
+			if sum != 0 {
+				sm.ColIdx[nnz] = col
+				sm.Data[nnz] = sum
+				nnz++
+			}
+		}
+
+		start = end
+		sm.RowPtr[i+1] = nnz
+	}
+	sm.ColIdx = sm.ColIdx[:nnz]
+	sm.Data = sm.Data[:nnz]
+
 	return nil
 }
 
+// ToFloat32 返回元素值转换为 float32 的副本，不与原矩阵共享存储
+// 绝对值过小的元素转换后可能变为0，可通过 Canonicalize 删除
+func (sm *SparseMatrixOf[T]) ToFloat32() *SparseMatrix32 {
+	return convertSparse[float32](sm)
+}
+
+// ToFloat64 返回元素值转换为 float64 的副本，不与原矩阵共享存储
+func (sm *SparseMatrixOf[T]) ToFloat64() *SparseMatrix {
+	return convertSparse[float64](sm)
+}
+
+// convertSparse 复制稀疏矩阵并将元素值转换为类型U
+func convertSparse[U, T Float](sm *SparseMatrixOf[T]) *SparseMatrixOf[U] {
+	data := make([]U, len(sm.Data))
+	for k, v := range sm.Data {
+		data[k] = U(v)
+	}
+	return &SparseMatrixOf[U]{
+		Rows:         sm.Rows,
+		Cols:         sm.Cols,
+		Data:         data,
+		RowPtr:       append([]int(nil), sm.RowPtr...),
+		ColIdx:       append([]int(nil), sm.ColIdx...),
+		FeatureNames: copyNames(sm.FeatureNames),
+	}
+}
+
 // ToGonumDense 将稀疏矩阵转换为Gonum库的Dense矩阵格式
-func (sm *SparseMatrix) ToGonumDense() (*mat.Dense, error) {
+func (sm *SparseMatrixOf[T]) ToGonumDense() (*mat.Dense, error) {
 	if err := sm.Validate(); err != nil {
 		return nil, err
 	}
+	if err := sm.checkNonZeroDims(); err != nil {
+		return nil, err
+	}
 
-	sparse := mat.NewDense(sm.Rows, sm.Cols, nil)
+	dense := mat.NewDense(sm.Rows, sm.Cols, nil)
 
-	for i := 0; i < len(sm.Data); i++ {
-		sparse.Set(sm.RowIdx[i], sm.ColIdx[i], sm.Data[i])
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		// 重复的元素累加，与 At 的结果一致
+		for k, col := range cols {
+			dense.Set(i, col, dense.At(i, col)+float64(data[k]))
+		}
 	}
 
-	return sparse, nil
+	return dense, nil
 }
 
 // ToDense 将稀疏矩阵转换为普通的二维密集矩阵
-func (sm *SparseMatrix) ToDense() [][]float32 {
+func (sm *SparseMatrixOf[T]) ToDense() [][]float32 {
 	// 初始化稠密矩阵
 	dense := make([][]float32, sm.Rows)
 	for i := range dense {
 		dense[i] = make([]float32, sm.Cols)
 	}
 
-	// 填充稠密矩阵
-	for i := 0; i < len(sm.Data); i++ {
-		row := sm.RowIdx[i]
-		col := sm.ColIdx[i]
-		dense[row][col] = float32(sm.Data[i])
+	// 逐行填充稠密矩阵
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		for k, col := range cols {
+			dense[i][col] += float32(data[k])
+		}
 	}
 
 	return dense
@@ -71,264 +237,122 @@ func (sm *SparseMatrix) ToDense() [][]float32 {
 
 // Binarize 将矩阵二值化
 // 所有非零元素的值都设置为1
-func (sm *SparseMatrix) Binarize() {
+func (sm *SparseMatrixOf[T]) Binarize() {
 	for i := 0; i < len(sm.Data); i++ {
 		sm.Data[i] = 1 // 将所有非零元素的值设置为 1
 	}
 }
 
 // AddConstantFeature 为矩阵添加一个常数特征列
-// 在矩阵最后添加一列，其值都为指定的常数
-func (sm *SparseMatrix) AddConstantFeature(constant float64) {
+// 在矩阵最后添加一列，其值都为指定的常数；矩阵带有特征名时，新列的特征名为空字符串
+func (sm *SparseMatrixOf[T]) AddConstantFeature(constant T) {
+	if sm.FeatureNames != nil {
+		sm.FeatureNames = append(copyNames(sm.FeatureNames), "")
+	}
+
 	// 增加一列新的特征
 	sm.Cols++ // 列数也增加
+	newCol := sm.Cols - 1
 
-	// 为每一行添加新的常数特征列
-	sm.Data = append(sm.Data, make([]float64, sm.Rows)...)
-	sm.RowIdx = append(sm.RowIdx, make([]int, sm.Rows)...)
-	sm.ColIdx = append(sm.ColIdx, make([]int, sm.Rows)...)
+	// 每行多出一个元素，新列位于每行末尾，行内列索引依然有序
+	nnz := len(sm.Data) + sm.Rows
+	newData := make([]T, 0, nnz)
+	newColIdx := make([]int, 0, nnz)
+	newRowPtr := make([]int, sm.Rows+1)
 
-	// 将数据填充到新添加的常数特征列
-	dataIdx := len(sm.Data) - sm.Rows // 新的特征列的起始位置
 	for i := 0; i < sm.Rows; i++ {
-		sm.Data[dataIdx+i] = constant
-		sm.RowIdx[dataIdx+i] = i           // 行索引
-		sm.ColIdx[dataIdx+i] = sm.Cols - 1 // 新特征的列索引
+		cols, data := sm.Row(i)
+		newData = append(newData, data...)
+		newColIdx = append(newColIdx, cols...)
+		newData = append(newData, constant)
+		newColIdx = append(newColIdx, newCol)
+		newRowPtr[i+1] = len(newData)
 	}
+
+	sm.Data = newData
+	sm.ColIdx = newColIdx
+	sm.RowPtr = newRowPtr
 }
 
 // String 实现Stringer接口，提供矩阵的字符串表示
 // 按行列顺序输出所有非零元素
-func (s *SparseMatrix) String() string {
-	// 创建entry结构来存储矩阵元素
-	type entry struct {
-		row   int
-		col   int
-		value float64
-	}
-
-	// 收集所有非零元素
-	entries := make([]entry, len(s.Data))
-	for i := range s.Data {
-		entries[i] = entry{
-			row:   s.RowIdx[i],
-			col:   s.ColIdx[i],
-			value: s.Data[i],
-		}
-	}
-
-	// 按行索引和列索引排序
-	sort.Slice(entries, func(i, j int) bool {
-		if entries[i].row != entries[j].row {
-			return entries[i].row < entries[j].row
-		}
-		return entries[i].col < entries[j].col
-	})
-
-	// 构建输出字符串
+func (s *SparseMatrixOf[T]) String() string {
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("numFeatures: %d\n", s.Cols))
-	for _, e := range entries {
-		result.WriteString(fmt.Sprintf("  (%d, %d)\t%g\n",
-			e.row, e.col, e.value))
+	for i := 0; i < s.Rows; i++ {
+		cols, data := s.Row(i)
+		for k, col := range cols {
+			result.WriteString(fmt.Sprintf("  (%d, %d)\t%g\n",
+				i, col, data[k]))
+		}
 	}
 	return result.String()
 }
 
 // MergeRows 合并多个稀疏矩阵的行
 // 要求所有矩阵的列数（特征维度）相同
-func (sm *SparseMatrix) MergeRows(other ...*SparseMatrix) error {
-	// 验证所有矩阵的特征维度是否匹配
-	for _, m := range other {
-		if sm.Cols != m.Cols {
-			return fmt.Errorf("特征维度不匹配: %d != %d", sm.Cols, m.Cols)
-		}
-	}
-
-	// 计算总数据量
-	totalData := len(sm.Data)
-	totalRows := sm.Rows
-	for _, m := range other {
-		totalData += len(m.Data)
-		totalRows += m.Rows
-	}
-
-	// 创建新的存储空间
-	newData := make([]float64, 0, totalData)
-	newRowIdx := make([]int, 0, totalData)
-	newColIdx := make([]int, 0, totalData)
-
-	// 复制第一个矩阵的数据
-	newData = append(newData, sm.Data...)
-	newColIdx = append(newColIdx, sm.ColIdx...)
-	newRowIdx = append(newRowIdx, sm.RowIdx...)
-
-	// 复制其他矩阵的数据，并调整行索引
-	currentRows := sm.Rows
-	for _, m := range other {
-		for i := 0; i < len(m.Data); i++ {
-			newData = append(newData, m.Data[i])
-			newColIdx = append(newColIdx, m.ColIdx[i])
-			newRowIdx = append(newRowIdx, m.RowIdx[i]+currentRows)
-		}
-		currentRows += m.Rows
+func (sm *SparseMatrixOf[T]) MergeRows(other ...*SparseMatrixOf[T]) error {
+	merged, err := MergeMultipleSparseMatrixRows(append([]*SparseMatrixOf[T]{sm}, other...)...)
+	if err != nil {
+		return err
 	}
 
 	// 更新原矩阵
-	sm.Rows = totalRows
-	sm.Data = newData
-	sm.RowIdx = newRowIdx
-	sm.ColIdx = newColIdx
+	*sm = *merged
 
 	return nil
 }
 
 // MergeCols 合并多个稀疏矩阵的列
 // 要求所有矩阵的行数相同
-func (sm *SparseMatrix) MergeCols(other ...*SparseMatrix) error {
-	// 验证所有矩阵的行数是否匹配
-	for _, m := range other {
-		if sm.Rows != m.Rows {
-			return fmt.Errorf("行数不匹配: %d != %d", sm.Rows, m.Rows)
-		}
-	}
-
-	// 计算总数据量和总列数
-	totalData := len(sm.Data)
-	totalCols := sm.Cols
-	for _, m := range other {
-		totalData += len(m.Data)
-		totalCols += m.Cols
-	}
-
-	// 创建新的存储空间
-	newData := make([]float64, 0, totalData)
-	newRowIdx := make([]int, 0, totalData)
-	newColIdx := make([]int, 0, totalData)
-
-	// 复制第一个矩阵的数据
-	newData = append(newData, sm.Data...)
-	newColIdx = append(newColIdx, sm.ColIdx...)
-	newRowIdx = append(newRowIdx, sm.RowIdx...)
-
-	// 复制其他矩阵的数据，并调整列索引
-	currentCols := sm.Cols
-	for _, m := range other {
-		for i := 0; i < len(m.Data); i++ {
-			newData = append(newData, m.Data[i])
-			newColIdx = append(newColIdx, m.ColIdx[i]+currentCols)
-			newRowIdx = append(newRowIdx, m.RowIdx[i])
-		}
-		currentCols += m.Cols
+func (sm *SparseMatrixOf[T]) MergeCols(other ...*SparseMatrixOf[T]) error {
+	merged, err := MergeMultipleSparseMatrixCols(append([]*SparseMatrixOf[T]{sm}, other...)...)
+	if err != nil {
+		return err
 	}
 
 	// 更新原矩阵
-	sm.Cols = totalCols
-	sm.Data = newData
-	sm.RowIdx = newRowIdx
-	sm.ColIdx = newColIdx
+	*sm = *merged
 
 	return nil
 }
 
-func (sm *SparseMatrix) ConvertToLibSVM(labels []int, zeroBase bool, precision int) (string, error) {
-	// 验证标签数量
-	if len(labels) != sm.Rows {
-		return "", fmt.Errorf("标签数量(%d)与矩阵行数(%d)不匹配", len(labels), sm.Rows)
-	}
-
-	// 验证精度参数
-	if precision < 0 {
-		precision = 6 // 默认保留6位小数
-	}
-
-	// 创建用于排序的entry结构
-	type entry struct {
-		row   int
-		col   int
-		value float64
-	}
-
-	// 收集所有非零元素并按行分组
-	rowEntries := make(map[int][]entry)
-	for i := range sm.Data {
-		row := sm.RowIdx[i]
-		rowEntries[row] = append(rowEntries[row], entry{
-			row:   row,
-			col:   sm.ColIdx[i],
-			value: sm.Data[i],
-		})
-	}
-
-	// 构建LibSVM格式字符串
+// ConvertToLibSVM 将稀疏矩阵转换为LibSVM格式的字符串
+// 整个结果保存在内存中，数据量较大时请使用 WriteLibSVM 流式写入
+func (sm *SparseMatrixOf[T]) ConvertToLibSVM(labels []int, zeroBase bool, precision int) (string, error) {
 	var sb strings.Builder
-	for i := 0; i < sm.Rows; i++ {
-		// 添加标签
-		sb.WriteString(fmt.Sprintf("%d", labels[i]))
-
-		// 获取当前行的所有特征
-		entries := rowEntries[i]
-
-		// 对当前行的特征按列索引排序
-		sort.Slice(entries, func(m, n int) bool {
-			return entries[m].col < entries[n].col
-		})
-		// 添加特征
-		for _, e := range entries {
-			featureIndex := e.col
-			if !zeroBase {
-				featureIndex++
-			}
-			// 对于值为1的特征，直接输出 index:1
-			if e.value == 1.0 {
-				sb.WriteString(fmt.Sprintf(" %d:1", featureIndex))
-			} else {
-				sb.WriteString(fmt.Sprintf(" %d:%."+fmt.Sprintf("%d", precision)+"f",
-					featureIndex, e.value))
-			}
-		}
-
-		if i < sm.Rows-1 {
-			sb.WriteString("\n")
-		}
+	if err := sm.WriteLibSVM(&sb, labels, zeroBase, precision); err != nil {
+		return "", err
 	}
 
-	return sb.String(), nil
+	// 最后一行不输出换行符
+	return strings.TrimSuffix(sb.String(), "\n"), nil
 }
 
 // DenseToSparse 将密集矩阵转换为稀疏矩阵
 // 只保存非零元素
-func DenseToSparse(dense [][]float64) *SparseMatrix {
-	var data []float64
-	var rowIdx []int
-	var colIdx []int
-
+func DenseToSparse[T Float](dense [][]T) *SparseMatrixOf[T] {
 	rows := len(dense)
 	cols := 0
 	if rows > 0 {
 		cols = len(dense[0])
 	}
 
+	sm := NewSparseMatrixOf[T](rows, cols)
 	for i := 0; i < rows; i++ {
 		for j := 0; j < cols; j++ {
 			if dense[i][j] != 0 {
-				data = append(data, dense[i][j])
-				rowIdx = append(rowIdx, i)
-				colIdx = append(colIdx, j)
+				sm.Data = append(sm.Data, dense[i][j])
+				sm.ColIdx = append(sm.ColIdx, j)
 			}
 		}
+		sm.RowPtr[i+1] = len(sm.Data)
 	}
 
-	return &SparseMatrix{
-		Rows:   rows,
-		Cols:   cols,
-		Data:   data,
-		RowIdx: rowIdx,
-		ColIdx: colIdx,
-	}
+	return sm
 }
 
-func MergeMultipleSparseMatrixCols(matrices ...*SparseMatrix) (*SparseMatrix, error) {
+func MergeMultipleSparseMatrixCols[T Float](matrices ...*SparseMatrixOf[T]) (*SparseMatrixOf[T], error) {
 	if len(matrices) == 0 {
 		return nil, fmt.Errorf("没有提供矩阵")
 	}
@@ -350,38 +374,55 @@ func MergeMultipleSparseMatrixCols(matrices ...*SparseMatrix) (*SparseMatrix, er
 	}
 
 	// 创建新的稀疏矩阵
-	merged := &SparseMatrix{
+	merged := &SparseMatrixOf[T]{
 		Rows:   numRows,
 		Cols:   totalCols,
-		Data:   make([]float64, 0, totalData),
-		RowIdx: make([]int, 0, totalData),
+		Data:   make([]T, 0, totalData),
+		RowPtr: make([]int, numRows+1),
 		ColIdx: make([]int, 0, totalData),
 	}
 
-	// 复制所有矩阵的数据，并调整列索引
-	currentColOffset := 0
-	for _, matrix := range matrices {
-		merged.Data = append(merged.Data, matrix.Data...)
-		for i := range matrix.ColIdx {
-			merged.RowIdx = append(merged.RowIdx, matrix.RowIdx[i])
-			merged.ColIdx = append(merged.ColIdx, matrix.ColIdx[i]+currentColOffset)
+	// 只要有一个矩阵带有特征名，结果就带有拼接后的特征名，没有特征名的矩阵对应的列名为空字符串
+	merged.FeatureNames = concatNames(matrices)
+
+	// 逐行拼接各矩阵的数据，并调整列索引
+	// 列偏移量递增，因此拼接后的行内列索引依然有序
+	for i := 0; i < numRows; i++ {
+		currentColOffset := 0
+		for _, matrix := range matrices {
+			cols, data := matrix.Row(i)
+			merged.Data = append(merged.Data, data...)
+			for _, col := range cols {
+				merged.ColIdx = append(merged.ColIdx, col+currentColOffset)
+			}
+			currentColOffset += matrix.Cols
 		}
-		currentColOffset += matrix.Cols
+		merged.RowPtr[i+1] = len(merged.Data)
 	}
 	return merged, nil
 }
 
-func MergeMultipleSparseMatrixRows(matrices ...*SparseMatrix) (*SparseMatrix, error) {
+func MergeMultipleSparseMatrixRows[T Float](matrices ...*SparseMatrixOf[T]) (*SparseMatrixOf[T], error) {
 	if len(matrices) == 0 {
 		return nil, fmt.Errorf("没有提供矩阵")
 	}
 
 	// 验证所有矩阵的特征维度是否匹配
+	// 带有特征名的矩阵之间特征名必须一致，结果使用第一个非nil的特征名
 	numFeatures := matrices[0].Cols
+	var names []string
 	for _, matrix := range matrices {
 		if matrix.Cols != numFeatures {
 			return nil, fmt.Errorf("特征维度不匹配: %d != %d", matrix.Cols, numFeatures)
 		}
+		if matrix.FeatureNames == nil {
+			continue
+		}
+		if names == nil {
+			names = matrix.FeatureNames
+		} else if !equalNames(names, matrix.FeatureNames) {
+			return nil, fmt.Errorf("特征名不一致，无法按行合并")
+		}
 	}
 
 	// 计算合并后的行数和数据容量
@@ -393,23 +434,24 @@ func MergeMultipleSparseMatrixRows(matrices ...*SparseMatrix) (*SparseMatrix, er
 	}
 
 	// 创建新的稀疏矩阵
-	merged := &SparseMatrix{
-		Rows:   totalRows,
-		Cols:   numFeatures,
-		Data:   make([]float64, 0, totalData),
-		RowIdx: make([]int, 0, totalData),
-		ColIdx: make([]int, 0, totalData),
+	merged := &SparseMatrixOf[T]{
+		Rows:         totalRows,
+		Cols:         numFeatures,
+		Data:         make([]T, 0, totalData),
+		RowPtr:       make([]int, 1, totalRows+1),
+		ColIdx:       make([]int, 0, totalData),
+		FeatureNames: copyNames(names),
 	}
 
-	// 复制所有矩阵的数据，并调整行索引
-	currentRowOffset := 0
+	// 依次追加所有矩阵的数据，并按已有元素数量平移行指针
 	for _, matrix := range matrices {
+		offset := len(merged.Data)
 		merged.Data = append(merged.Data, matrix.Data...)
-		for i := range matrix.RowIdx {
-			merged.RowIdx = append(merged.RowIdx, matrix.RowIdx[i]+currentRowOffset)
-			merged.ColIdx = append(merged.ColIdx, matrix.ColIdx[i])
+		merged.ColIdx = append(merged.ColIdx, matrix.ColIdx...)
+		for i := 0; i < matrix.Rows; i++ {
+			_, end := matrix.rowBounds(i)
+			merged.RowPtr = append(merged.RowPtr, end+offset)
 		}
-		currentRowOffset += matrix.Rows
 	}
 
 	return merged, nil
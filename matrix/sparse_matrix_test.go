@@ -1,6 +1,7 @@
 package matrix
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -18,21 +19,21 @@ func TestSparseMatrix_MergeRows(t *testing.T) {
 				Rows:   2,
 				Cols:   3,
 				Data:   []float64{1, 2},
-				RowIdx: []int{0, 1},
+				RowPtr: []int{0, 1, 2},
 				ColIdx: []int{0, 1},
 			},
 			matrix2: &SparseMatrix{
 				Rows:   2,
 				Cols:   3,
 				Data:   []float64{3, 4},
-				RowIdx: []int{0, 1},
+				RowPtr: []int{0, 1, 2},
 				ColIdx: []int{1, 2},
 			},
 			want: &SparseMatrix{
 				Rows:   4,
 				Cols:   3,
 				Data:   []float64{1, 2, 3, 4},
-				RowIdx: []int{0, 1, 2, 3},
+				RowPtr: []int{0, 1, 2, 3, 4},
 				ColIdx: []int{0, 1, 1, 2},
 			},
 			wantErr: false,
@@ -76,6 +77,10 @@ func TestSparseMatrix_MergeRows(t *testing.T) {
 						t.Errorf("MergeRows() Data[%d] = %v, want %v", i, tt.matrix1.Data[i], tt.want.Data[i])
 					}
 				}
+				// 检查行指针
+				if !reflect.DeepEqual(tt.matrix1.RowPtr, tt.want.RowPtr) {
+					t.Errorf("MergeRows() RowPtr = %v, want %v", tt.matrix1.RowPtr, tt.want.RowPtr)
+				}
 			}
 		})
 	}
@@ -95,22 +100,22 @@ func TestSparseMatrix_MergeCols(t *testing.T) {
 				Rows:   2,
 				Cols:   2,
 				Data:   []float64{1, 2},
-				RowIdx: []int{0, 1},
+				RowPtr: []int{0, 1, 2},
 				ColIdx: []int{0, 1},
 			},
 			matrix2: &SparseMatrix{
 				Rows:   2,
 				Cols:   2,
 				Data:   []float64{3, 4},
-				RowIdx: []int{0, 1},
+				RowPtr: []int{0, 1, 2},
 				ColIdx: []int{0, 1},
 			},
 			want: &SparseMatrix{
 				Rows:   2,
 				Cols:   4,
-				Data:   []float64{1, 2, 3, 4},
-				RowIdx: []int{0, 1, 0, 1},
-				ColIdx: []int{0, 1, 2, 3},
+				Data:   []float64{1, 3, 2, 4},
+				RowPtr: []int{0, 2, 4},
+				ColIdx: []int{0, 2, 1, 3},
 			},
 			wantErr: false,
 		},
@@ -153,11 +158,11 @@ func TestSparseMatrix_MergeCols(t *testing.T) {
 						t.Errorf("MergeCols() Data[%d] = %v, want %v", i, tt.matrix1.Data[i], tt.want.Data[i])
 					}
 				}
-				// 检查行列索引
-				for i := range tt.want.RowIdx {
-					if tt.matrix1.RowIdx[i] != tt.want.RowIdx[i] {
-						t.Errorf("MergeCols() RowIdx[%d] = %v, want %v", i, tt.matrix1.RowIdx[i], tt.want.RowIdx[i])
-					}
+				// 检查行指针和列索引
+				if !reflect.DeepEqual(tt.matrix1.RowPtr, tt.want.RowPtr) {
+					t.Errorf("MergeCols() RowPtr = %v, want %v", tt.matrix1.RowPtr, tt.want.RowPtr)
+				}
+				for i := range tt.want.ColIdx {
 					if tt.matrix1.ColIdx[i] != tt.want.ColIdx[i] {
 						t.Errorf("MergeCols() ColIdx[%d] = %v, want %v", i, tt.matrix1.ColIdx[i], tt.want.ColIdx[i])
 					}
@@ -166,3 +171,246 @@ func TestSparseMatrix_MergeCols(t *testing.T) {
 		})
 	}
 }
+
+func TestSparseMatrix_Row(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{0, 1, 0, 2},
+		{0, 0, 0, 0},
+		{3, 0, 4, 0},
+	})
+
+	wantCols := [][]int{{1, 3}, {}, {0, 2}}
+	wantData := [][]float64{{1, 2}, {}, {3, 4}}
+	for i := 0; i < sm.Rows; i++ {
+		cols, data := sm.Row(i)
+		if !reflect.DeepEqual(cols, wantCols[i]) {
+			t.Errorf("Row(%d) cols = %v, want %v", i, cols, wantCols[i])
+		}
+		if !reflect.DeepEqual(data, wantData[i]) {
+			t.Errorf("Row(%d) data = %v, want %v", i, data, wantData[i])
+		}
+	}
+}
+
+func TestSparseMatrix_AddConstantFeature(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{0, 1},
+		{0, 0},
+		{3, 0},
+	})
+	sm.AddConstantFeature(1)
+
+	want := &SparseMatrix{
+		Rows:   3,
+		Cols:   3,
+		Data:   []float64{1, 1, 1, 3, 1},
+		RowPtr: []int{0, 2, 3, 5},
+		ColIdx: []int{1, 2, 2, 0, 2},
+	}
+	if !reflect.DeepEqual(sm, want) {
+		t.Errorf("AddConstantFeature() = %+v, want %+v", sm, want)
+	}
+}
+
+func TestSparseMatrix_ConvertToLibSVM(t *testing.T) {
+	sm := DenseToSparse([][]float64{
+		{0, 1, 0.5},
+		{2, 0, 0},
+	})
+
+	got, err := sm.ConvertToLibSVM([]int{1, 0}, false, 2)
+	if err != nil {
+		t.Fatalf("ConvertToLibSVM() error = %v", err)
+	}
+	want := "1 2:1 3:0.50\n0 1:2.00"
+	if got != want {
+		t.Errorf("ConvertToLibSVM() = %q, want %q", got, want)
+	}
+
+	if _, err := sm.ConvertToLibSVM([]int{1}, false, 2); err == nil {
+		t.Error("ConvertToLibSVM() 标签数量不匹配时应返回错误")
+	}
+}
+
+func TestSparseMatrix_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sm      *SparseMatrix
+		wantErr bool
+	}{
+		{
+			name: "有效矩阵",
+			sm:   newTestSparse(),
+		},
+		{
+			name: "RowPtr为空的空矩阵",
+			sm:   &SparseMatrix{Rows: 2, Cols: 3},
+		},
+		{
+			name: "无序且重复的列索引",
+			sm:   &SparseMatrix{Rows: 1, Cols: 3, Data: []float64{1, 2, 3}, RowPtr: []int{0, 3}, ColIdx: []int{2, 0, 2}},
+		},
+		{
+			name:    "列索引等于列数",
+			sm:      &SparseMatrix{Rows: 1, Cols: 3, Data: []float64{1}, RowPtr: []int{0, 1}, ColIdx: []int{3}},
+			wantErr: true,
+		},
+		{
+			name:    "负的列索引",
+			sm:      &SparseMatrix{Rows: 1, Cols: 3, Data: []float64{1}, RowPtr: []int{0, 1}, ColIdx: []int{-1}},
+			wantErr: true,
+		},
+		{
+			name:    "负的行数",
+			sm:      &SparseMatrix{Rows: -1, Cols: 3},
+			wantErr: true,
+		},
+		{
+			name:    "Data与ColIdx长度不一致",
+			sm:      &SparseMatrix{Rows: 1, Cols: 3, Data: []float64{1, 2}, RowPtr: []int{0, 1}, ColIdx: []int{0}},
+			wantErr: true,
+		},
+		{
+			name:    "RowPtr长度错误",
+			sm:      &SparseMatrix{Rows: 2, Cols: 3, Data: []float64{1}, RowPtr: []int{0, 1}, ColIdx: []int{0}},
+			wantErr: true,
+		},
+		{
+			name:    "RowPtr末尾与元素个数不一致",
+			sm:      &SparseMatrix{Rows: 1, Cols: 3, Data: []float64{1, 2}, RowPtr: []int{0, 1}, ColIdx: []int{0, 1}},
+			wantErr: true,
+		},
+		{
+			name:    "RowPtr不单调",
+			sm:      &SparseMatrix{Rows: 2, Cols: 3, Data: []float64{1, 2}, RowPtr: []int{0, 3, 2}, ColIdx: []int{0, 1}},
+			wantErr: true,
+		},
+		{
+			name:    "RowPtr为空但有元素",
+			sm:      &SparseMatrix{Rows: 1, Cols: 3, Data: []float64{1}, ColIdx: []int{0}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sm.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSparseMatrix_Canonicalize(t *testing.T) {
+	sm := &SparseMatrix{
+		Rows:   3,
+		Cols:   4,
+		Data:   []float64{5, 1, 0, 2, 3, -3, 4, 1},
+		RowPtr: []int{0, 4, 6, 8},
+		ColIdx: []int{3, 0, 1, 0, 2, 2, 1, 1},
+	}
+	if err := sm.Canonicalize(); err != nil {
+		t.Fatalf("Canonicalize() error = %v", err)
+	}
+
+	// 第0行: 重复的列0相加、显式的0被删除、按列排序；第1行: 重复元素相加为0后被删除
+	want := &SparseMatrix{
+		Rows:   3,
+		Cols:   4,
+		Data:   []float64{3, 5, 5},
+		RowPtr: []int{0, 2, 2, 3},
+		ColIdx: []int{0, 3, 1},
+	}
+	if !reflect.DeepEqual(sm, want) {
+		t.Errorf("Canonicalize() = %+v, want %+v", sm, want)
+	}
+
+	// 规范化前后 ToGonumDense 的结果一致
+	dup := &SparseMatrix{Rows: 1, Cols: 2, Data: []float64{1, 2}, RowPtr: []int{0, 2}, ColIdx: []int{1, 1}}
+	dense, err := dup.ToGonumDense()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dense.At(0, 1) != 3 {
+		t.Errorf("ToGonumDense() 重复元素应相加，得到 %v", dense.At(0, 1))
+	}
+
+	empty := &SparseMatrix{Rows: 2, Cols: 3}
+	if err := empty.Canonicalize(); err != nil {
+		t.Fatalf("Canonicalize() error = %v", err)
+	}
+	if !reflect.DeepEqual(empty.RowPtr, []int{0, 0, 0}) {
+		t.Errorf("Canonicalize() RowPtr = %v, want [0 0 0]", empty.RowPtr)
+	}
+
+	invalid := &SparseMatrix{Rows: 1, Cols: 1, Data: []float64{1}, RowPtr: []int{0, 1}, ColIdx: []int{1}}
+	if err := invalid.Canonicalize(); err == nil {
+		t.Error("Canonicalize() 无效矩阵应返回错误")
+	}
+}
+
+func TestSparseMatrix32(t *testing.T) {
+	a := DenseToSparse([][]float32{{0.1, 0, 2}, {0, 0, 0}})
+	b := DenseToSparse([][]float32{{0, 1, 0}})
+	if err := a.MergeRows(b); err != nil {
+		t.Fatalf("MergeRows() error = %v", err)
+	}
+	want := &SparseMatrix32{
+		Rows:   3,
+		Cols:   3,
+		Data:   []float32{0.1, 2, 1},
+		RowPtr: []int{0, 2, 2, 3},
+		ColIdx: []int{0, 2, 1},
+	}
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("MergeRows() = %+v, want %+v", a, want)
+	}
+
+	// float32 按其自身精度格式化，0.1 不会输出为 0.10000000149011612
+	got, err := a.ConvertToLibSVM([]int{1, 0, 1}, false, -1)
+	if err != nil {
+		t.Fatalf("ConvertToLibSVM() error = %v", err)
+	}
+	if wantStr := "1 1:0.100000 3:2.000000\n0\n1 2:1"; got != wantStr {
+		t.Errorf("ConvertToLibSVM() = %q, want %q", got, wantStr)
+	}
+
+	if v := a.At(0, 0); v != float64(float32(0.1)) {
+		t.Errorf("At(0, 0) = %v, want %v", v, float64(float32(0.1)))
+	}
+}
+
+func TestSparseMatrix_ToFloat32(t *testing.T) {
+	sm := DenseToSparse([][]float64{{0.5, 0, 1e-300}, {0, 3, 0}})
+
+	f32 := sm.ToFloat32()
+	want := &SparseMatrix32{
+		Rows:   2,
+		Cols:   3,
+		Data:   []float32{0.5, 0, 3},
+		RowPtr: []int{0, 2, 3},
+		ColIdx: []int{0, 2, 1},
+	}
+	if !reflect.DeepEqual(f32, want) {
+		t.Errorf("ToFloat32() = %+v, want %+v", f32, want)
+	}
+
+	// 下溢为0的元素可以通过 Canonicalize 删除
+	if err := f32.Canonicalize(); err != nil {
+		t.Fatal(err)
+	}
+	if f32.NNZ() != 2 {
+		t.Errorf("Canonicalize() 后 NNZ() = %d, want 2", f32.NNZ())
+	}
+
+	f64 := f32.ToFloat64()
+	if wantF64 := DenseToSparse([][]float64{{0.5, 0, 0}, {0, 3, 0}}); !reflect.DeepEqual(f64, wantF64) {
+		t.Errorf("ToFloat64() = %+v, want %+v", f64, wantF64)
+	}
+
+	// 副本不与原矩阵共享存储
+	f64.ColIdx[0] = 2
+	if f32.ColIdx[0] != 0 {
+		t.Error("ToFloat64() 的结果不应与原矩阵共享存储")
+	}
+}
@@ -0,0 +1,97 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// Norm 表示行归一化使用的范数
+type Norm int
+
+const (
+	NormNone Norm = iota // 不归一化
+	NormL1               // 除以元素绝对值之和
+	NormL2               // 除以元素平方和的平方根
+	NormMax              // 除以元素绝对值的最大值
+)
+
+// String 返回范数的名称，与 sklearn.preprocessing.normalize 的 norm 参数一致
+func (n Norm) String() string {
+	switch n {
+	case NormNone:
+		return "none"
+	case NormL1:
+		return "l1"
+	case NormL2:
+		return "l2"
+	case NormMax:
+		return "max"
+	}
+	return fmt.Sprintf("Norm(%d)", int(n))
+}
+
+// NormalizeRows 按指定的范数对每一行进行原地归一化
+// 与 sklearn.preprocessing.normalize 一致，范数为0的行保持不变
+func (sm *SparseMatrixOf[T]) NormalizeRows(norm Norm) error {
+	if norm == NormNone {
+		return nil
+	}
+	if norm != NormL1 && norm != NormL2 && norm != NormMax {
+		return fmt.Errorf("不支持的范数: %v", norm)
+	}
+
+	for i := 0; i < sm.Rows; i++ {
+		_, data := sm.Row(i)
+		var n float64
+		for _, x := range data {
+			v := float64(x)
+			switch norm {
+			case NormL1:
+				n += math.Abs(v)
+			case NormL2:
+				n += v * v
+			case NormMax:
+				n = math.Max(n, math.Abs(v))
+			}
+		}
+		if norm == NormL2 {
+			n = math.Sqrt(n)
+		}
+		if n == 0 {
+			continue
+		}
+		for k := range data {
+			data[k] = T(float64(data[k]) / n)
+		}
+	}
+	return nil
+}
+
+// ScaleCols 将第j列的所有元素原地乘以 weights[j]
+// 常用于按IDF或信息增益分数对特征加权，weights 的长度必须等于矩阵列数
+func (sm *SparseMatrixOf[T]) ScaleCols(weights []float64) error {
+	if len(weights) != sm.Cols {
+		return fmt.Errorf("权重长度(%d)与矩阵列数(%d)不匹配", len(weights), sm.Cols)
+	}
+
+	for k, col := range sm.ColIdx {
+		sm.Data[k] = T(float64(sm.Data[k]) * weights[col])
+	}
+	return nil
+}
+
+// Log1p 将所有非零元素原地替换为 log(1+x)，常用于对词频做次线性缩放
+// 由于 log(1+0)=0，矩阵的稀疏结构保持不变；小于-1的元素会变为NaN
+func (sm *SparseMatrixOf[T]) Log1p() {
+	for k, v := range sm.Data {
+		sm.Data[k] = T(math.Log1p(float64(v)))
+	}
+}
+
+// Sqrt 将所有非零元素原地替换为其平方根
+// 由于 sqrt(0)=0，矩阵的稀疏结构保持不变；负数元素会变为NaN
+func (sm *SparseMatrixOf[T]) Sqrt() {
+	for k, v := range sm.Data {
+		sm.Data[k] = T(math.Sqrt(float64(v)))
+	}
+}
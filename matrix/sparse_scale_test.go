@@ -0,0 +1,86 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSparseMatrix_NormalizeRows(t *testing.T) {
+	tests := []struct {
+		norm Norm
+		want [][]float64
+	}{
+		{
+			norm: NormNone,
+			want: [][]float64{{3, 0, -4}, {0, 0, 0}, {0, 2, 0}},
+		},
+		{
+			norm: NormL1,
+			want: [][]float64{{3.0 / 7, 0, -4.0 / 7}, {0, 0, 0}, {0, 1, 0}},
+		},
+		{
+			norm: NormL2,
+			want: [][]float64{{0.6, 0, -0.8}, {0, 0, 0}, {0, 1, 0}},
+		},
+		{
+			norm: NormMax,
+			want: [][]float64{{0.75, 0, -1}, {0, 0, 0}, {0, 1, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.norm.String(), func(t *testing.T) {
+			sm := DenseToSparse([][]float64{{3, 0, -4}, {0, 0, 0}, {0, 2, 0}})
+			if err := sm.NormalizeRows(tt.norm); err != nil {
+				t.Fatalf("NormalizeRows() error = %v", err)
+			}
+			want := DenseToSparse(tt.want)
+			if !mat.EqualApprox(sm, want, 1e-12) {
+				t.Errorf("NormalizeRows(%v) = %v, want %v", tt.norm, mat.Formatted(sm), mat.Formatted(want))
+			}
+		})
+	}
+
+	if err := newTestSparse().NormalizeRows(Norm(10)); err == nil {
+		t.Error("NormalizeRows() 不支持的范数应返回错误")
+	}
+}
+
+func TestSparseMatrix_ScaleCols(t *testing.T) {
+	sm := newTestSparse()
+	if err := sm.ScaleCols([]float64{2, 0.5, -1, 0}); err != nil {
+		t.Fatalf("ScaleCols() error = %v", err)
+	}
+	want := DenseToSparse([][]float64{
+		{2, 0, -2, 0},
+		{0, 0, 0, 0},
+		{0, 1.5, 0, 0},
+		{10, 0, 0, 0},
+		{0, 3.5, -8, 0},
+	})
+	if !mat.Equal(sm, want) {
+		t.Errorf("ScaleCols() = %v, want %v", mat.Formatted(sm), mat.Formatted(want))
+	}
+
+	if err := sm.ScaleCols([]float64{1}); err == nil {
+		t.Error("ScaleCols() 权重长度不匹配时应返回错误")
+	}
+}
+
+func TestSparseMatrix_Log1pSqrt(t *testing.T) {
+	sm := DenseToSparse([][]float64{{0, 3}, {8, 0}})
+	sm.Log1p()
+	want := DenseToSparse([][]float64{{0, math.Log(4)}, {math.Log(9), 0}})
+	if !mat.EqualApprox(sm, want, 1e-12) {
+		t.Errorf("Log1p() = %v, want %v", mat.Formatted(sm), mat.Formatted(want))
+	}
+
+	sm = DenseToSparse([][]float64{{0, 4}, {2.25, 0}})
+	sm.Sqrt()
+	want = DenseToSparse([][]float64{{0, 2}, {1.5, 0}})
+	if !mat.Equal(sm, want) {
+		t.Errorf("Sqrt() = %v, want %v", mat.Formatted(sm), mat.Formatted(want))
+	}
+}
@@ -0,0 +1,134 @@
+package matrix
+
+import "fmt"
+
+// SelectRows 按给定的行索引选取行，返回新的稀疏矩阵
+// 结果的第k行为原矩阵的第rows[k]行，行索引可以重复、无序，常用于训练集/测试集划分
+func (sm *SparseMatrixOf[T]) SelectRows(rows []int) (*SparseMatrixOf[T], error) {
+	nnz := 0
+	for _, i := range rows {
+		if i < 0 || i >= sm.Rows {
+			return nil, fmt.Errorf("行索引 %d 超出范围 %d", i, sm.Rows)
+		}
+		start, end := sm.rowBounds(i)
+		nnz += end - start
+	}
+
+	result := &SparseMatrixOf[T]{
+		Rows:         len(rows),
+		Cols:         sm.Cols,
+		Data:         make([]T, 0, nnz),
+		RowPtr:       make([]int, 1, len(rows)+1),
+		ColIdx:       make([]int, 0, nnz),
+		FeatureNames: copyNames(sm.FeatureNames),
+	}
+	for _, i := range rows {
+		cols, data := sm.Row(i)
+		result.Data = append(result.Data, data...)
+		result.ColIdx = append(result.ColIdx, cols...)
+		result.RowPtr = append(result.RowPtr, len(result.Data))
+	}
+
+	return result, nil
+}
+
+// SliceRows 选取 [start, end) 范围内的连续行，返回新的稀疏矩阵
+// 结果不与原矩阵共享底层存储
+func (sm *SparseMatrixOf[T]) SliceRows(start, end int) (*SparseMatrixOf[T], error) {
+	if start < 0 || end > sm.Rows || start > end {
+		return nil, fmt.Errorf("无效的行范围 [%d, %d)，矩阵行数为 %d", start, end, sm.Rows)
+	}
+
+	first, last := 0, 0
+	if len(sm.RowPtr) > 0 {
+		first, last = sm.RowPtr[start], sm.RowPtr[end]
+	}
+
+	result := &SparseMatrixOf[T]{
+		Rows:         end - start,
+		Cols:         sm.Cols,
+		Data:         append([]T(nil), sm.Data[first:last]...),
+		RowPtr:       make([]int, end-start+1),
+		ColIdx:       append([]int(nil), sm.ColIdx[first:last]...),
+		FeatureNames: copyNames(sm.FeatureNames),
+	}
+	for i := start; i < end; i++ {
+		_, rowEnd := sm.rowBounds(i)
+		result.RowPtr[i-start+1] = rowEnd - first
+	}
+
+	return result, nil
+}
+
+// SelectCols 按给定的列索引选取列，返回新的稀疏矩阵
+// 结果的第k列为原矩阵的第cols[k]列，列索引和特征名会重新编号；列索引可以重复、无序，常用于特征子集选择
+func (sm *SparseMatrixOf[T]) SelectCols(cols []int) (*SparseMatrixOf[T], error) {
+	// 以链表记录每个原列对应的所有新列：head[原列] 为第一个新列，next[新列] 为下一个新列
+	head := make([]int, sm.Cols)
+	for j := range head {
+		head[j] = -1
+	}
+	next := make([]int, len(cols))
+	for k := len(cols) - 1; k >= 0; k-- {
+		j := cols[k]
+		if j < 0 || j >= sm.Cols {
+			return nil, fmt.Errorf("列索引 %d 超出范围 %d", j, sm.Cols)
+		}
+		next[k] = head[j]
+		head[j] = k
+	}
+
+	result := &SparseMatrixOf[T]{
+		Rows:   sm.Rows,
+		Cols:   len(cols),
+		RowPtr: make([]int, sm.Rows+1),
+	}
+	if sm.FeatureNames != nil {
+		result.FeatureNames = make([]string, len(cols))
+		for k, j := range cols {
+			result.FeatureNames[k] = sm.FeatureNames[j]
+		}
+	}
+	for i := 0; i < sm.Rows; i++ {
+		rowCols, data := sm.Row(i)
+		start := len(result.Data)
+		for k, col := range rowCols {
+			for newCol := head[col]; newCol >= 0; newCol = next[newCol] {
+				result.ColIdx = append(result.ColIdx, newCol)
+				result.Data = append(result.Data, data[k])
+			}
+		}
+		// 列的选取顺序可能与原顺序不同，需要重新排序
+		sortRow(result.ColIdx[start:], result.Data[start:])
+		result.RowPtr[i+1] = len(result.Data)
+	}
+
+	return result, nil
+}
+
+// SelectRowsMask 选取 mask 中为 true 的行，mask 的长度必须等于矩阵行数
+func (sm *SparseMatrixOf[T]) SelectRowsMask(mask []bool) (*SparseMatrixOf[T], error) {
+	if len(mask) != sm.Rows {
+		return nil, fmt.Errorf("掩码长度(%d)与矩阵行数(%d)不匹配", len(mask), sm.Rows)
+	}
+	return sm.SelectRows(maskIndices(mask))
+}
+
+// SelectColsMask 选取 mask 中为 true 的列，mask 的长度必须等于矩阵列数
+func (sm *SparseMatrixOf[T]) SelectColsMask(mask []bool) (*SparseMatrixOf[T], error) {
+	if len(mask) != sm.Cols {
+		return nil, fmt.Errorf("掩码长度(%d)与矩阵列数(%d)不匹配", len(mask), sm.Cols)
+	}
+	return sm.SelectCols(maskIndices(mask))
+}
+
+// maskIndices 返回 mask 中为 true 的位置
+func maskIndices(mask []bool) []int {
+	indices := make([]int, 0, len(mask))
+	for k, ok := range mask {
+		if ok {
+			indices = append(indices, k)
+		}
+	}
+	return indices
+}
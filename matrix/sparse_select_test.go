@@ -0,0 +1,138 @@
+package matrix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSparseMatrix_SelectRows(t *testing.T) {
+	sm := newTestSparse()
+
+	tests := []struct {
+		name string
+		rows []int
+		want [][]float64
+	}{
+		{
+			name: "无序",
+			rows: []int{3, 0},
+			want: [][]float64{{5, 0, 0, 6}, {1, 0, 2, 0}},
+		},
+		{
+			name: "重复和空行",
+			rows: []int{4, 1, 4},
+			want: [][]float64{{0, 7, 8, 0}, {0, 0, 0, 0}, {0, 7, 8, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sm.SelectRows(tt.rows)
+			if err != nil {
+				t.Fatalf("SelectRows() error = %v", err)
+			}
+			if want := DenseToSparse(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("SelectRows() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	if _, err := sm.SelectRows([]int{5}); err == nil {
+		t.Error("SelectRows() 行索引越界时应返回错误")
+	}
+}
+
+func TestSparseMatrix_SliceRows(t *testing.T) {
+	sm := newTestSparse()
+
+	got, err := sm.SliceRows(1, 4)
+	if err != nil {
+		t.Fatalf("SliceRows() error = %v", err)
+	}
+	want := DenseToSparse([][]float64{{0, 0, 0, 0}, {0, 3, 0, 4}, {5, 0, 0, 6}})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SliceRows() = %+v, want %+v", got, want)
+	}
+
+	// 结果不与原矩阵共享存储
+	got.Data[0] = 100
+	if sm.Data[2] != 3 {
+		t.Error("SliceRows() 的结果不应与原矩阵共享存储")
+	}
+
+	empty, err := sm.SliceRows(5, 5)
+	if err != nil {
+		t.Fatalf("SliceRows() error = %v", err)
+	}
+	if empty.Rows != 0 || empty.Cols != 4 || empty.NNZ() != 0 {
+		t.Errorf("SliceRows(5, 5) = %+v, want 0x4 的空矩阵", empty)
+	}
+
+	for _, r := range [][2]int{{-1, 2}, {3, 2}, {0, 6}} {
+		if _, err := sm.SliceRows(r[0], r[1]); err == nil {
+			t.Errorf("SliceRows(%d, %d) 应返回错误", r[0], r[1])
+		}
+	}
+}
+
+func TestSparseMatrix_SelectCols(t *testing.T) {
+	sm := newTestSparse()
+
+	tests := []struct {
+		name string
+		cols []int
+		want [][]float64
+	}{
+		{
+			name: "有序",
+			cols: []int{1, 3},
+			want: [][]float64{{0, 0}, {0, 0}, {3, 4}, {0, 6}, {7, 0}},
+		},
+		{
+			name: "无序且重复",
+			cols: []int{2, 0, 2},
+			want: [][]float64{{2, 1, 2}, {0, 0, 0}, {0, 0, 0}, {0, 5, 0}, {8, 0, 8}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sm.SelectCols(tt.cols)
+			if err != nil {
+				t.Fatalf("SelectCols() error = %v", err)
+			}
+			if want := DenseToSparse(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("SelectCols() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	if _, err := sm.SelectCols([]int{4}); err == nil {
+		t.Error("SelectCols() 列索引越界时应返回错误")
+	}
+}
+
+func TestSparseMatrix_SelectMask(t *testing.T) {
+	sm := newTestSparse()
+
+	rows, err := sm.SelectRowsMask([]bool{true, false, false, true, false})
+	if err != nil {
+		t.Fatalf("SelectRowsMask() error = %v", err)
+	}
+	if want := DenseToSparse([][]float64{{1, 0, 2, 0}, {5, 0, 0, 6}}); !reflect.DeepEqual(rows, want) {
+		t.Errorf("SelectRowsMask() = %+v, want %+v", rows, want)
+	}
+
+	cols, err := sm.SelectColsMask([]bool{false, true, true, false})
+	if err != nil {
+		t.Fatalf("SelectColsMask() error = %v", err)
+	}
+	if want := DenseToSparse([][]float64{{0, 2}, {0, 0}, {3, 0}, {0, 0}, {7, 8}}); !reflect.DeepEqual(cols, want) {
+		t.Errorf("SelectColsMask() = %+v, want %+v", cols, want)
+	}
+
+	if _, err := sm.SelectRowsMask([]bool{true}); err == nil {
+		t.Error("SelectRowsMask() 掩码长度不匹配时应返回错误")
+	}
+	if _, err := sm.SelectColsMask([]bool{true}); err == nil {
+		t.Error("SelectColsMask() 掩码长度不匹配时应返回错误")
+	}
+}
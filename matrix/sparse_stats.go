@@ -0,0 +1,78 @@
+package matrix
+
+import "math"
+
+// ColumnStats 表示稀疏矩阵每一列的统计量
+// 未存储的元素按0参与统计，结果与对稠密矩阵逐列计算一致；无论元素类型如何，统计量均为 float64
+type ColumnStats struct {
+	NNZ  []int     // 每列存储的非零元素个数
+	Sum  []float64 // 每列元素之和
+	Mean []float64 // 每列元素的均值
+	Var  []float64 // 每列元素的总体方差（除以行数），与 StandardScaler 和 numpy.var 一致
+	Min  []float64 // 每列元素的最小值
+	Max  []float64 // 每列元素的最大值
+}
+
+// ColumnStats 只遍历一次非零元素，计算每一列的统计量，无需转换为稠密矩阵
+// 方差使用Welford算法累计后再与未存储的0合并，避免大数相减带来的精度损失；
+// 要求矩阵中没有重复的(行, 列)元素（可先调用 Canonicalize）；矩阵没有行时均值、方差、最值均为0
+func (sm *SparseMatrixOf[T]) ColumnStats() *ColumnStats {
+	stats := &ColumnStats{
+		NNZ:  make([]int, sm.Cols),
+		Sum:  make([]float64, sm.Cols),
+		Mean: make([]float64, sm.Cols),
+		Var:  make([]float64, sm.Cols),
+		Min:  make([]float64, sm.Cols),
+		Max:  make([]float64, sm.Cols),
+	}
+	for j := 0; j < sm.Cols; j++ {
+		stats.Min[j] = math.Inf(1)
+		stats.Max[j] = math.Inf(-1)
+	}
+
+	// 先在 Mean/Var 中累计非零元素的均值和偏差平方和
+	for k, col := range sm.ColIdx {
+		v := float64(sm.Data[k])
+		stats.NNZ[col]++
+		stats.Sum[col] += v
+		delta := v - stats.Mean[col]
+		stats.Mean[col] += delta / float64(stats.NNZ[col])
+		stats.Var[col] += delta * (v - stats.Mean[col])
+		stats.Min[col] = math.Min(stats.Min[col], v)
+		stats.Max[col] = math.Max(stats.Max[col], v)
+	}
+
+	// 与未存储的0合并
+	n := float64(sm.Rows)
+	for j := 0; j < sm.Cols; j++ {
+		nnz := stats.NNZ[j]
+		if nnz < sm.Rows {
+			stats.Min[j] = math.Min(stats.Min[j], 0)
+			stats.Max[j] = math.Max(stats.Max[j], 0)
+		}
+		if sm.Rows == 0 {
+			stats.Min[j], stats.Max[j] = 0, 0
+			stats.Mean[j], stats.Var[j] = 0, 0
+			continue
+		}
+
+		meanNZ := stats.Mean[j]
+		mean := stats.Sum[j] / n
+		zeros := n - float64(nnz)
+		m2 := stats.Var[j] + float64(nnz)*(meanNZ-mean)*(meanNZ-mean) + zeros*mean*mean
+		stats.Mean[j] = mean
+		stats.Var[j] = m2 / n
+	}
+
+	return stats
+}
+
+// RowNNZ 返回每一行存储的非零元素个数
+func (sm *SparseMatrixOf[T]) RowNNZ() []int {
+	counts := make([]int, sm.Rows)
+	for i := range counts {
+		start, end := sm.rowBounds(i)
+		counts[i] = end - start
+	}
+	return counts
+}
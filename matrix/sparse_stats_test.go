@@ -0,0 +1,77 @@
+package matrix
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSparseMatrix_ColumnStats(t *testing.T) {
+	dense := [][]float64{
+		{1, 0, 2, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, -3, 0, 4, 0},
+		{5, 0, 0, 6, 0},
+		{0, 7, 8, 1e8, 0},
+	}
+	stats := DenseToSparse(dense).ColumnStats()
+
+	// 逐列在稠密矩阵上计算期望值
+	n := float64(len(dense))
+	for j := range dense[0] {
+		var nnz int
+		var sum, sumSq float64
+		min, max := math.Inf(1), math.Inf(-1)
+		for i := range dense {
+			v := dense[i][j]
+			if v != 0 {
+				nnz++
+			}
+			sum += v
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+		mean := sum / n
+		for i := range dense {
+			sumSq += (dense[i][j] - mean) * (dense[i][j] - mean)
+		}
+
+		if stats.NNZ[j] != nnz {
+			t.Errorf("NNZ[%d] = %d, want %d", j, stats.NNZ[j], nnz)
+		}
+		if stats.Sum[j] != sum || stats.Min[j] != min || stats.Max[j] != max {
+			t.Errorf("第%d列 Sum/Min/Max = %v/%v/%v, want %v/%v/%v",
+				j, stats.Sum[j], stats.Min[j], stats.Max[j], sum, min, max)
+		}
+		if math.Abs(stats.Mean[j]-mean) > 1e-9 {
+			t.Errorf("Mean[%d] = %v, want %v", j, stats.Mean[j], mean)
+		}
+		if math.Abs(stats.Var[j]-sumSq/n) > 1e-9*math.Max(1, sumSq/n) {
+			t.Errorf("Var[%d] = %v, want %v", j, stats.Var[j], sumSq/n)
+		}
+	}
+}
+
+func TestSparseMatrix_ColumnStats_Empty(t *testing.T) {
+	stats := NewSparseMatrix(0, 2).ColumnStats()
+	want := &ColumnStats{
+		NNZ:  []int{0, 0},
+		Sum:  []float64{0, 0},
+		Mean: []float64{0, 0},
+		Var:  []float64{0, 0},
+		Min:  []float64{0, 0},
+		Max:  []float64{0, 0},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("ColumnStats() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestSparseMatrix_RowNNZ(t *testing.T) {
+	if got, want := newTestSparse().RowNNZ(), []int{2, 0, 2, 2, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RowNNZ() = %v, want %v", got, want)
+	}
+	if got, want := (&SparseMatrix{Rows: 2, Cols: 3}).RowNNZ(), []int{0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RowNNZ() = %v, want %v", got, want)
+	}
+}
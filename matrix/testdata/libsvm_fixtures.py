@@ -0,0 +1,27 @@
+# 生成 libsvm_test.go 使用的 svmlight 测试数据
+import numpy as np
+from scipy import sparse
+from sklearn.datasets import dump_svmlight_file
+
+X = sparse.csr_matrix(np.array([
+    [0.5, 0, 1.25, 0],
+    [0, 3, 0, 0],
+    [0, 0, 0, 0],
+    [0.1, 0, 0, -2e-07],
+]))
+
+# 单标签 + qid，特征索引从0开始
+y = np.array([1, 0, 2, 1])
+qid = np.array([7, 7, 8, 8])
+dump_svmlight_file(X, y, "sklearn_qid.svmlight", zero_based=True,
+                   query_id=qid, comment="qid fixture")
+
+# 多标签，特征索引从1开始
+Y = np.array([
+    [0, 1, 0, 1],
+    [1, 0, 0, 0],
+    [0, 0, 1, 0],
+    [1, 1, 1, 0],
+])
+dump_svmlight_file(X, Y, "sklearn_multilabel.svmlight", zero_based=False,
+                   multilabel=True)
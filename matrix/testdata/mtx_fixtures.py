@@ -0,0 +1,29 @@
+# 生成 matrix_market_test.go 使用的 Matrix Market 测试数据
+import numpy as np
+from scipy import sparse
+from scipy.io import mmread, mmwrite
+
+real_general = sparse.coo_matrix(np.array([
+    [0.5, 0, -1.25, 0],
+    [0, 0, 0, 3e-08],
+    [0, 7, 0, 1],
+]))
+mmwrite("real_general.mtx", real_general, comment=" scipy.io.mmwrite fixture")
+
+integer_symmetric = sparse.coo_matrix(np.array([
+    [2, -1, 0],
+    [-1, 0, 5],
+    [0, 5, 4],
+]))
+mmwrite("integer_symmetric.mtx", integer_symmetric, field="integer", symmetry="symmetric")
+
+pattern_general = sparse.coo_matrix(np.array([
+    [0, 1, 0],
+    [1, 0, 1],
+]))
+mmwrite("pattern_general.mtx", pattern_general, field="pattern")
+
+# 读回并检查
+for name in ["real_general.mtx", "integer_symmetric.mtx", "pattern_general.mtx"]:
+    print(name)
+    print(mmread(name).toarray())
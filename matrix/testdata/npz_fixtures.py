@@ -0,0 +1,25 @@
+# 生成 npz_test.go 使用的 scipy.sparse.save_npz 测试数据
+import numpy as np
+from scipy import sparse
+
+dense = np.array([
+    [0.5, 0, -1.25, 0],
+    [0, 0, 0, 3e-08],
+    [0, 7, 0, 1],
+])
+sparse.save_npz("scipy_csr.npz", sparse.csr_matrix(dense))
+sparse.save_npz("scipy_csc.npz", sparse.csc_matrix(dense), compressed=False)
+
+coo = sparse.coo_matrix(np.array([
+    [0, 1.5, 0],
+    [2, 0, 0],
+    [0, 0, -3],
+], dtype=np.float32))
+sparse.save_npz("scipy_coo.npz", coo)
+
+# 读回并检查
+for name in ["scipy_csr.npz", "scipy_csc.npz", "scipy_coo.npz"]:
+    m = sparse.load_npz(name)
+    print(name, m.format, m.dtype)
+    print(m.toarray())
+
@@ -10,3 +10,12 @@ type FeatureScore struct {
 func (fs FeatureScore) String() string {
 	return fmt.Sprintf("%s: %f", fs.Feature, fs.Score)
 }
+
+// Tokenize 使用分词函数 tokenizer 对所有文本分词，结果的第i个元素对应 texts[i]
+func Tokenize(texts []string, tokenizer func(string) []string) [][]string {
+	tokens := make([][]string, len(texts))
+	for i, text := range texts {
+		tokens[i] = tokenizer(text)
+	}
+	return tokens
+}